@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/olaysco/domain-search-llm/internal/provider"
+)
+
+// invalidatingCache is a cache whose entries can be dropped by domain or TLD.
+type invalidatingCache interface {
+	InvalidateDomain(ctx context.Context, domain string) (int, error)
+	InvalidateTLD(ctx context.Context, tld string) (int, error)
+	Clear(ctx context.Context) (int, error)
+}
+
+// adminHandler serves the operator endpoints under /admin/. Every request must carry the admin token as a
+// bearer token. Invalidation applies to the prices of every source and to the availability cache.
+//
+//	GET  /admin/cache/stats                     cache counters
+//	POST /admin/cache/invalidate?domain=x.com   drop one domain
+//	POST /admin/cache/invalidate?tld=com        drop every domain under a TLD
+//	POST /admin/cache/invalidate                drop everything
+func adminHandler(token string, backend provider.Cache, prices map[string]*provider.PriceCache, availability *provider.CachedAvailabilityChecker) http.Handler {
+	caches := []invalidatingCache{availability}
+	priceStats := func() map[string]provider.CacheLookupStats {
+		stats := make(map[string]provider.CacheLookupStats, len(prices))
+		for name, cache := range prices {
+			stats[name] = cache.Stats()
+		}
+		return stats
+	}
+	for _, cache := range prices {
+		caches = append(caches, cache)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/cache/stats", func(w http.ResponseWriter, r *http.Request) {
+		stats := map[string]any{
+			"prices":       priceStats(),
+			"availability": availability.Stats(),
+		}
+		if memory, ok := backend.(*provider.MemoryCache); ok {
+			stats["memory"] = memory.Stats()
+		}
+		writeJSON(w, stats)
+	})
+	mux.HandleFunc("POST /admin/cache/invalidate", func(w http.ResponseWriter, r *http.Request) {
+		domain := r.URL.Query().Get("domain")
+		tld := r.URL.Query().Get("tld")
+		if domain != "" && tld != "" {
+			http.Error(w, "use either domain or tld", http.StatusBadRequest)
+			return
+		}
+
+		removed := 0
+		for _, cache := range caches {
+			var (
+				n   int
+				err error
+			)
+			switch {
+			case domain != "":
+				n, err = cache.InvalidateDomain(r.Context(), domain)
+			case tld != "":
+				n, err = cache.InvalidateTLD(r.Context(), tld)
+			default:
+				n, err = cache.Clear(r.Context())
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			removed += n
+		}
+		writeJSON(w, map[string]int{"removed": removed})
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
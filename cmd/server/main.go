@@ -11,19 +11,22 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
 	"github.com/joho/godotenv"
 	domainsearch "github.com/olaysco/domain-search-llm/internal/domainsearch"
+	"github.com/olaysco/domain-search-llm/internal/embedding"
 	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
 	client "github.com/olaysco/domain-search-llm/internal/grpc"
 	"github.com/olaysco/domain-search-llm/internal/llm"
 	"github.com/olaysco/domain-search-llm/internal/logger"
 	"github.com/olaysco/domain-search-llm/internal/provider"
+	"github.com/olaysco/domain-search-llm/internal/rdap"
+	"github.com/olaysco/domain-search-llm/internal/scoring"
 	pricepb "github.com/openprovider/contracts/v2/product/price"
-	"github.com/tmc/langchaingo/llms/mistral"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 )
@@ -31,13 +34,42 @@ import (
 func main() {
 	_ = godotenv.Load()
 	var (
-		grpcAddr     = flag.String("grpc-addr", ":9090", "address for the gRPC server")
-		httpAddr     = flag.String("http-addr", envOrDefault("HTTP_ADDR", ":8080"), "address for the HTTP server that hosts the UI and gRPC-Web")
-		staticDir    = flag.String("static-dir", "web/dist", "directory that holds the built static web assets")
-		priceAddr    = flag.String("price-addr", envOrDefault("PRICE_SERVICE_ADDR", ""), "address for the upstream price gRPC service")
-		priceAddrTls = flag.Bool("price-addr-tls", envOrDefault("PRICE_SERVICE_ADDR_TLS", "true") == "true", "address for the price service supports tls")
+		grpcAddr       = flag.String("grpc-addr", ":9090", "address for the gRPC server")
+		httpAddr       = flag.String("http-addr", envOrDefault("HTTP_ADDR", ":8080"), "address for the HTTP server that hosts the UI and gRPC-Web")
+		staticDir      = flag.String("static-dir", "web/dist", "directory that holds the built static web assets")
+		priceAddr      = flag.String("price-addr", envOrDefault("PRICE_SERVICE_ADDR", ""), "address for the upstream price gRPC service")
+		priceAddrTls   = flag.Bool("price-addr-tls", envOrDefault("PRICE_SERVICE_ADDR_TLS", "true") == "true", "address for the price service supports tls")
+		rdapSource     = flag.String("rdap-bootstrap", envOrDefault("RDAP_BOOTSTRAP", ""), "path or URL of the IANA RDAP dns.json bootstrap file (defaults to the live IANA file)")
+		rdapRefresh    = flag.Duration("rdap-bootstrap-refresh", 24*time.Hour, "interval for reloading the RDAP bootstrap file (0 disables refreshing)")
+		bulkMax        = flag.Int("bulk-max-domains", 50, "maximum number of domains accepted by BulkCheckAvailability")
+		maxResults     = flag.Int("max-results", 50, "maximum number of suggestions a search may request with filter.domain.quantity")
+		genRounds      = flag.Int("generation-rounds", 3, "maximum number of LLM rounds used to reach the requested number of suggestions")
+		fxRates        = flag.String("fx-rates", envOrDefault("FX_RATES", ""), "path or URL of the exchange rates used when the price service cannot quote the requested currency")
+		fxRatesFormat  = flag.String("fx-rates-format", "", "format of the exchange rates document: json, csv or ecb (inferred from the extension when empty)")
+		fxRatesRefresh = flag.Duration("fx-rates-refresh", time.Hour, "interval for reloading the exchange rates")
+		scoreWeights   = flag.String("score-weights", envOrDefault("SCORE_WEIGHTS", ""), "relevance signal weights, e.g. lexical=0.3,length=0.1,pronounceability=0.15,tld=0.2,semantic=0.25")
+		cacheSpec      = flag.String("cache", envOrDefault("CACHE", "memory"), "price and availability cache: memory, bolt:<path> or redis://<host>:<port>/<db>")
+		cacheSize      = flag.Int("cache-size", 10000, "maximum number of entries kept by the memory cache")
+		cacheTTL       = flag.Duration("price-cache-ttl", 15*time.Minute, "how long cached prices stay fresh")
+		cacheErrorTTL  = flag.Duration("price-cache-error-ttl", time.Minute, "how long price service errors are cached")
+		availableTTL   = flag.Duration("availability-cache-ttl", 5*time.Minute, "how long an available answer from RDAP is cached")
+		takenTTL       = flag.Duration("availability-cache-taken-ttl", time.Hour, "how long a taken answer from RDAP is cached")
+		adminToken     = flag.String("admin-token", envOrDefault("ADMIN_TOKEN", ""), "bearer token for the /admin endpoints (disabled when empty)")
+		priceSelection = flag.String("price-selection", envOrDefault("PRICE_SELECTION", provider.SelectCheapest), "how to choose between price sources: cheapest or priority")
+		sourceTimeout  = flag.Duration("price-source-timeout", 10*time.Second, "how long to wait for each price source")
+		sourceReload   = flag.Duration("price-source-reload", 5*time.Second, "interval for checking price table files for changes (0 disables reloading)")
+		priceTimeout   = flag.Duration("price-timeout", 10*time.Second, "deadline of each call to a price gRPC service (0 disables it)")
+		priceAttempts  = flag.Int("price-max-attempts", 3, "attempts per price service call failing with UNAVAILABLE or RESOURCE_EXHAUSTED (1 disables retries)")
+		priceBackoff   = flag.Duration("price-retry-backoff", 100*time.Millisecond, "initial backoff between price service retries, doubled up to 2s")
+		breakerFails   = flag.Int("price-breaker-threshold", 5, "consecutive price service failures that open the circuit breaker (0 disables it)")
+		breakerCool    = flag.Duration("price-breaker-cooldown", 30*time.Second, "how long an open circuit fails price lookups fast before probing the service again")
+		priceSources   priceSourceList
 	)
+	flag.Var(&priceSources, "price-source", "additional price source as [name=]grpc://host:port, [name=]grpcs://host or [name=]file:prices.csv (repeatable, env PRICE_SOURCES)")
 	flag.Parse()
+	if len(priceSources) == 0 {
+		_ = priceSources.Set(os.Getenv("PRICE_SOURCES"))
+	}
 	log := logger.New()
 	defer log.Sync()
 
@@ -47,52 +79,182 @@ func main() {
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
-	if *priceAddr == "" {
-		log.Fatal("price service address is not configured (set --price-addr or PRICE_SERVICE_ADDR)")
-	}
-	socketAddrPrefix := ""
-	if *priceAddrTls {
-		socketAddrPrefix = ":443"
+	if *priceAddr == "" && len(priceSources) == 0 {
+		log.Fatal("price service address is not configured (set --price-addr, PRICE_SERVICE_ADDR or --price-source)")
 	}
-	socketAddr := fmt.Sprintf("%s%s", *priceAddr, socketAddrPrefix)
-	log.Info(socketAddr)
+	var sourceSpecs []priceSource
+	if *priceAddr != "" {
+		socketAddrPrefix := ""
+		if *priceAddrTls {
+			socketAddrPrefix = ":443"
+		}
+		socketAddr := fmt.Sprintf("%s%s", *priceAddr, socketAddrPrefix)
+		log.Info(socketAddr)
 
-	cfg := &client.Config{
-		Target:       socketAddr,
-		ServerName:   *priceAddr,
-		Insecure:     !*priceAddrTls,
-		WaitForReady: true,
+		sourceSpecs = append(sourceSpecs, priceSource{
+			Name: "openprovider",
+			Client: &client.Config{
+				Target:       socketAddr,
+				ServerName:   *priceAddr,
+				Insecure:     !*priceAddrTls,
+				WaitForReady: true,
+			},
+		})
+	}
+	for _, spec := range priceSources {
+		source, err := parsePriceSource(spec)
+		if err != nil {
+			log.Fatal("invalid price source ", zap.Error(err))
+		}
+		for _, existing := range sourceSpecs {
+			if existing.Name == source.Name {
+				log.Fatal("duplicate price source ", zap.String("source", source.Name))
+			}
+		}
+		sourceSpecs = append(sourceSpecs, source)
+	}
+	for _, spec := range sourceSpecs {
+		if spec.Client != nil {
+			spec.Client.CallTimeout = *priceTimeout
+			spec.Client.MaxAttempts = *priceAttempts
+			spec.Client.InitialBackoff = *priceBackoff
+			spec.Client.BreakerThreshold = *breakerFails
+			spec.Client.BreakerCooldown = *breakerCool
+		}
 	}
 
-	priceConn, err := client.New(cfg)
+	var converter provider.CurrencyConverter
+	if *fxRates != "" {
+		rateSource, err := provider.NewRateSource(*fxRates, *fxRatesFormat)
+		if err != nil {
+			log.Fatal("invalid exchange rate source ", zap.Error(err))
+		}
+		rateConverter, err := provider.NewRateConverter(ctx, rateSource)
+		if err != nil {
+			log.Fatal("unable to load exchange rates ", zap.Error(err))
+		}
+		go rateConverter.Run(ctx, *fxRatesRefresh, func(err error) {
+			log.Warn("exchange rates refresh", zap.Error(err))
+		})
+		converter = rateConverter
+	}
+	cacheBackend, err := provider.NewCache(*cacheSpec, *cacheSize)
 	if err != nil {
-		log.Fatal("unable to connect to price nameserver ", zap.Error(err))
+		log.Fatal("unable to open cache ", zap.Error(err))
 	}
+	defer cacheBackend.Close()
+
+	var sources []provider.PriceSource
+	priceCaches := make(map[string]*provider.PriceCache)
+	for _, spec := range sourceSpecs {
+		if spec.File != "" {
+			table, err := provider.NewPriceTableProvider(spec.File, "", converter)
+			if err != nil {
+				log.Fatal("unable to load price table ", zap.String("source", spec.Name), zap.Error(err))
+			}
+			go table.Run(ctx, *sourceReload, func(err error) {
+				log.Warn("price table reload", zap.String("source", spec.Name), zap.Error(err))
+			})
+			sources = append(sources, provider.PriceSource{Name: spec.Name, Provider: table})
+			continue
+		}
 
-	defer priceConn.Close()
-	priceSvc := provider.NewPriceService(pricepb.NewPriceServiceClient(priceConn))
+		priceConn, err := client.New(spec.Client)
+		if err != nil {
+			log.Fatal("unable to connect to price nameserver ", zap.String("source", spec.Name), zap.Error(err))
+		}
+		defer priceConn.Close()
+
+		priceCaches[spec.Name] = provider.NewPriceCache(cacheBackend, provider.PriceCacheConfig{
+			TTL:       *cacheTTL,
+			ErrorTTL:  *cacheErrorTTL,
+			Namespace: spec.Name,
+		})
+		sources = append(sources, provider.PriceSource{
+			Name:     spec.Name,
+			Provider: provider.NewPriceService(pricepb.NewPriceServiceClient(priceConn), converter, priceCaches[spec.Name]),
+		})
+	}
+	priceSvc := sources[0].Provider
+	if len(sources) > 1 {
+		aggregator, err := provider.NewAggregator(sources, provider.AggregatorConfig{
+			Selection:     *priceSelection,
+			SourceTimeout: *sourceTimeout,
+			OnError: func(source string, err error) {
+				log.Warn("price source failed", zap.String("source", source), zap.Error(err))
+			},
+		})
+		if err != nil {
+			log.Fatal("invalid price aggregation ", zap.Error(err))
+		}
+		priceSvc = aggregator
+	}
 
 	grpcServer := grpc.NewServer()
-	llmConfig := &llm.Config{
+	llmConfig := llm.Config{
+		AIProvider: os.Getenv("AI_PROVIDER"),
 		AIEndpoint: os.Getenv("AI_ENDPOINT"),
 		AIAPIKey:   os.Getenv("AI_API_KEY"),
 		AIModel:    os.Getenv("AI_MODEL"),
 	}
-	suggesterService := llm.NewLLMSuggester(*llmConfig)
-
-	llmModel, err := mistral.New(mistral.WithModel(llmConfig.AIModel), mistral.WithAPIKey(llmConfig.AIAPIKey))
+	llmBackend, err := llm.NewBackend(llmConfig)
 	if err != nil {
-		log.Fatal("unable to create LLM model ", zap.Error(err))
+		log.Fatal("unable to create LLM backend ", zap.Error(err))
 	}
+	suggesterService := llm.NewLLMSuggester(llmBackend)
 
-	priceCheckerTool := llm.NewPriceCheckerTool(priceSvc)
-	avaialbilityTool := llm.NewAvailabilityCheckerTool()
+	priceSearch, ok := priceSvc.(provider.PriceSearchProvider)
+	if !ok {
+		log.Fatal("price provider does not support unary lookups")
+	}
+	priceCheckerTool := llm.NewPriceCheckerTool(priceSearch)
+	rdapClient, err := rdap.New(rdap.Config{
+		BootstrapSource: *rdapSource,
+		RefreshInterval: *rdapRefresh,
+		OnFallback: func(err error) {
+			log.Warn("rdap bootstrap unavailable, using the bundled fallback list", zap.Error(err))
+		},
+	})
+	if err != nil {
+		log.Fatal("unable to load RDAP bootstrap ", zap.Error(err))
+	}
+	go rdapClient.Run(ctx, func(err error) {
+		log.Warn("rdap bootstrap refresh", zap.Error(err))
+	})
+	availabilityChecker := provider.NewCachedAvailabilityChecker(rdapClient, cacheBackend, provider.AvailabilityCacheConfig{
+		AvailableTTL: *availableTTL,
+		TakenTTL:     *takenTTL,
+	})
+	avaialbilityTool := llm.NewAvailabilityCheckerTool(availabilityChecker)
 	llmTools := map[string]llm.LLMTools{
 		priceCheckerTool.Name(): priceCheckerTool,
 		avaialbilityTool.Name(): avaialbilityTool,
 	}
-	agentService := llm.NewLLMAgent(llmModel, llmTools)
-	domainsearchv1.RegisterDomainSearchServiceServer(grpcServer, domainsearch.NewSearchService(suggesterService, agentService, priceSvc))
+	agentService := llm.NewLLMAgent(llmBackend, llmTools)
+
+	weights, err := scoring.ParseWeights(*scoreWeights)
+	if err != nil {
+		log.Fatal("invalid score weights ", zap.Error(err))
+	}
+	var semantic scoring.SemanticSimilarity
+	if embeddingProvider := os.Getenv("EMBEDDING_PROVIDER"); embeddingProvider != "" {
+		embedder, err := embedding.New(embedding.Config{
+			Provider: embeddingProvider,
+			Endpoint: os.Getenv("EMBEDDING_ENDPOINT"),
+			APIKey:   envOrDefault("EMBEDDING_API_KEY", llmConfig.AIAPIKey),
+			Model:    os.Getenv("EMBEDDING_MODEL"),
+		})
+		if err != nil {
+			log.Fatal("unable to create embedder ", zap.Error(err))
+		}
+		semantic = embedding.NewSimilarity(embedding.NewCachedEmbedder(embedder, 0))
+	}
+	scorer := scoring.New(weights, semantic)
+	domainsearchv1.RegisterDomainSearchServiceServer(grpcServer, domainsearch.NewSearchService(suggesterService, agentService, priceSvc, availabilityChecker, scorer, log, domainsearch.Config{
+		MaxBulkDomains:   *bulkMax,
+		MaxResults:       *maxResults,
+		GenerationRounds: *genRounds,
+	}))
 
 	grpcLis, err := net.Listen("tcp", *grpcAddr)
 	if err != nil {
@@ -112,12 +274,18 @@ func main() {
 	)
 
 	staticHandler := spaHandler(*staticDir)
+	var admin http.Handler = http.NotFoundHandler()
+	if *adminToken != "" {
+		admin = adminHandler(*adminToken, cacheBackend, priceCaches, availabilityChecker)
+	}
 	rootHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch {
 		case grpcWebServer.IsGrpcWebRequest(r),
 			grpcWebServer.IsGrpcWebSocketRequest(r),
 			grpcWebServer.IsAcceptableGrpcCorsRequest(r):
 			grpcWebServer.ServeHTTP(w, r)
+		case strings.HasPrefix(r.URL.Path, "/admin/"):
+			admin.ServeHTTP(w, r)
 		default:
 			staticHandler.ServeHTTP(w, r)
 		}
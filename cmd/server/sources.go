@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	client "github.com/olaysco/domain-search-llm/internal/grpc"
+)
+
+// priceSourceList collects the repeatable --price-source flag. A single value may also hold a comma
+// separated list, as used by the PRICE_SOURCES variable.
+type priceSourceList []string
+
+func (l *priceSourceList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *priceSourceList) Set(value string) error {
+	for _, spec := range strings.Split(value, ",") {
+		if spec = strings.TrimSpace(spec); spec != "" {
+			*l = append(*l, spec)
+		}
+	}
+	return nil
+}
+
+// priceSource describes one configured price source: either an Openprovider-compatible gRPC service or a
+// static price table file.
+type priceSource struct {
+	Name   string
+	Client *client.Config
+	File   string
+}
+
+// parsePriceSource reads a "[name=]grpc://host:port", "[name=]grpcs://host[:port]" or "[name=]file:path"
+// source. Without a name, gRPC sources are named after their host and files "file".
+func parsePriceSource(spec string) (priceSource, error) {
+	var source priceSource
+	rawURL := strings.TrimSpace(spec)
+	if name, rest, ok := strings.Cut(rawURL, "="); ok && !strings.Contains(name, ":") {
+		source.Name = strings.TrimSpace(name)
+		rawURL = strings.TrimSpace(rest)
+	}
+
+	if path, ok := strings.CutPrefix(rawURL, "file:"); ok {
+		if path == "" {
+			return priceSource{}, fmt.Errorf("price source %q: file path is required", spec)
+		}
+		if source.Name == "" {
+			source.Name = "file"
+		}
+		source.File = path
+		return source, nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return priceSource{}, fmt.Errorf("price source %q: %w", spec, err)
+	}
+	if source.Name == "" {
+		source.Name = u.Hostname()
+	}
+	source.Client = &client.Config{ServerName: u.Hostname(), WaitForReady: true}
+	switch u.Scheme {
+	case "grpc":
+		if u.Port() == "" {
+			return priceSource{}, fmt.Errorf("price source %q: grpc:// requires a port", spec)
+		}
+		source.Client.Insecure = true
+		source.Client.Target = u.Host
+	case "grpcs":
+		source.Client.Target = u.Host
+		if u.Port() == "" {
+			source.Client.Target = net.JoinHostPort(u.Hostname(), "443")
+		}
+	default:
+		return priceSource{}, fmt.Errorf("price source %q: unsupported scheme %q", spec, u.Scheme)
+	}
+	return source, nil
+}
@@ -0,0 +1,154 @@
+// Package domainname normalizes and validates domain names produced by the LLM or sent by API callers
+// before they reach availability and price lookups.
+package domainname
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/idna"
+	"golang.org/x/net/publicsuffix"
+)
+
+const (
+	maxDomainLength = 253
+	maxLabelLength  = 63
+)
+
+var (
+	ErrEmpty            = errors.New("domain is empty")
+	ErrTooLong          = errors.New("domain exceeds 253 characters")
+	ErrLabelLength      = errors.New("label must be between 1 and 63 characters")
+	ErrInvalidCharacter = errors.New("label contains characters other than letters, digits and hyphens")
+	ErrHyphen           = errors.New("label starts or ends with a hyphen or has hyphens in positions 3 and 4")
+	ErrUnknownTLD       = errors.New("suffix is not an ICANN TLD from the public suffix list")
+	ErrPrivateSuffix    = errors.New("suffix is a privately operated entry of the public suffix list")
+	ErrNotRegistrable   = errors.New("domain is not a registrable name directly under its public suffix")
+	ErrInvalidIDN       = errors.New("internationalized label is not valid under IDNA2008")
+)
+
+// Normalize repairs the usual formatting noise in a domain name and validates the result against the
+// RFC 1035 / RFC 5891 label rules and the ICANN section of the public suffix list. Internationalized names
+// are converted to their IDNA2008 A-label form, so the result is always ASCII.
+//
+// Repairs: surrounding whitespace, letter case, URL schemes and paths, a "www." prefix, trailing dots,
+// whitespace inside labels and underscores (read as hyphens).
+func Normalize(raw string) (string, error) {
+	name := strings.ToLower(strings.TrimSpace(raw))
+	if i := strings.Index(name, "://"); i != -1 {
+		name = name[i+3:]
+	}
+	if i := strings.IndexAny(name, "/?#"); i != -1 {
+		name = name[:i]
+	}
+	name = strings.TrimPrefix(name, "www.")
+	name = strings.TrimRight(name, ".")
+	name = strings.Join(strings.Fields(name), "")
+	name = strings.ReplaceAll(name, "_", "-")
+
+	ascii, err := ToASCII(name)
+	if err != nil {
+		return "", fmt.Errorf("%q: %w", raw, err)
+	}
+	name = ascii
+
+	if err := Validate(name); err != nil {
+		return "", fmt.Errorf("%q: %w", raw, err)
+	}
+	return name, nil
+}
+
+// Validate checks an already normalized, lowercase domain name. Names under a private suffix such as
+// blogspot.com are subdomains handed out by that operator, not names a registrar can sell, so they are
+// rejected with ErrPrivateSuffix.
+func Validate(name string) error {
+	if name == "" {
+		return ErrEmpty
+	}
+	if len(name) > maxDomainLength {
+		return ErrTooLong
+	}
+	for _, label := range strings.Split(name, ".") {
+		if err := validateLabel(label); err != nil {
+			return err
+		}
+	}
+
+	_, icann := publicsuffix.PublicSuffix(name)
+	if !icann {
+		if _, tldKnown := publicsuffix.PublicSuffix(name[strings.LastIndex(name, ".")+1:]); tldKnown {
+			return ErrPrivateSuffix
+		}
+		return ErrUnknownTLD
+	}
+	if registrable, err := publicsuffix.EffectiveTLDPlusOne(name); err != nil || registrable != name {
+		return ErrNotRegistrable
+	}
+	return nil
+}
+
+// validateLabel applies the LDH rule of RFC 1035 together with the hyphen restrictions of RFC 5891
+// section 4.2.3.1.
+func validateLabel(label string) error {
+	if len(label) == 0 || len(label) > maxLabelLength {
+		return ErrLabelLength
+	}
+	for i := 0; i < len(label); i++ {
+		c := label[i]
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+			return ErrInvalidCharacter
+		}
+	}
+	if label[0] == '-' || label[len(label)-1] == '-' {
+		return ErrHyphen
+	}
+	if len(label) >= 4 && label[2:4] == "--" && !strings.HasPrefix(label, "xn--") {
+		return ErrHyphen
+	}
+	return nil
+}
+
+// ToASCII converts a lowercase domain name to its A-label form using the IDNA2008 registration profile.
+// Plain ASCII names are returned unchanged, A-labels are checked for valid punycode.
+func ToASCII(name string) (string, error) {
+	if !IsIDN(name) && !hasNonASCII(name) {
+		return name, nil
+	}
+	ascii, err := idna.Registration.ToASCII(name)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidIDN, err)
+	}
+	return ascii, nil
+}
+
+// ToUnicode returns the U-label form of a domain name, or the name itself when it cannot be decoded.
+func ToUnicode(name string) string {
+	if !IsIDN(name) {
+		return name
+	}
+	unicode, err := idna.Registration.ToUnicode(name)
+	if err != nil {
+		return name
+	}
+	return unicode
+}
+
+// IsIDN reports whether any label of an ASCII domain name is an A-label.
+func IsIDN(name string) bool {
+	for _, label := range strings.Split(name, ".") {
+		if strings.HasPrefix(label, "xn--") {
+			return true
+		}
+	}
+	return false
+}
+
+func hasNonASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package domainname
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    string
+		wantErr error
+	}{
+		{raw: "brand.com", want: "brand.com"},
+		{raw: "  Brand.COM  ", want: "brand.com"},
+		{raw: "https://brand.com/pricing?ref=ai#top", want: "brand.com"},
+		{raw: "http://www.brand.io", want: "brand.io"},
+		{raw: "www.brand.io", want: "brand.io"},
+		{raw: "brand.com.", want: "brand.com"},
+		{raw: "brand.com..", want: "brand.com"},
+		{raw: "my_brand.com", want: "my-brand.com"},
+		{raw: "my brand .com", want: "mybrand.com"},
+		{raw: "my\tbrand.co.uk", want: "mybrand.co.uk"},
+		{raw: "bücher.de", want: "xn--bcher-kva.de"},
+		{raw: "xn--bcher-kva.de", want: "xn--bcher-kva.de"},
+		{raw: "straße.de", want: "xn--strae-oqa.de"},
+		{raw: "", wantErr: ErrEmpty},
+		{raw: "https://", wantErr: ErrEmpty},
+		{raw: "-brand.com", wantErr: ErrHyphen},
+		{raw: "brand-.com", wantErr: ErrHyphen},
+		{raw: "ab--cd.com", wantErr: ErrHyphen},
+		{raw: "brand!.com", wantErr: ErrInvalidCharacter},
+		{raw: "a..com", wantErr: ErrLabelLength},
+		{raw: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa.com", wantErr: ErrLabelLength},
+		{raw: "brand.notatld", wantErr: ErrUnknownTLD},
+		{raw: "brand.blogspot.com", wantErr: ErrPrivateSuffix},
+		{raw: "brand.github.io", wantErr: ErrPrivateSuffix},
+		{raw: "shop.brand.com", wantErr: ErrNotRegistrable},
+		{raw: "co.uk", wantErr: ErrNotRegistrable},
+		{raw: "\u0301brand.com", wantErr: ErrInvalidIDN},
+		{raw: "a\u200db.com", wantErr: ErrInvalidIDN},
+		{raw: "xn--a.com", wantErr: ErrInvalidIDN},
+	}
+	for _, tt := range tests {
+		got, err := Normalize(tt.raw)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Normalize(%q) = %q, %v; want error %v", tt.raw, got, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Normalize(%q) = %q, %v; want %q", tt.raw, got, err, tt.want)
+		}
+	}
+}
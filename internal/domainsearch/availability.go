@@ -0,0 +1,165 @@
+package domainsearch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/olaysco/domain-search-llm/internal/domainname"
+	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
+	"github.com/olaysco/domain-search-llm/internal/provider"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultMaxBulkDomains = 50
+
+// CheckAvailability resolves availability and price for a single, caller-provided domain.
+func (s *SearchService) CheckAvailability(ctx context.Context, req *domainsearchv1.CheckAvailabilityRequest) (*domainsearchv1.DomainAvailability, error) {
+	domain, err := domainname.Normalize(req.GetDomain())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid domain: %v", err)
+	}
+	return s.lookupDomain(ctx, domain, req.GetCurrencyCode()), nil
+}
+
+// BulkCheckAvailability resolves availability and price for every requested domain and streams one result per
+// domain as soon as it is ready. Lookup failures are reported on the affected result only.
+func (s *SearchService) BulkCheckAvailability(req *domainsearchv1.BulkCheckAvailabilityRequest, stream domainsearchv1.DomainSearchService_BulkCheckAvailabilityServer) error {
+	domains := uniqueDomains(req.GetDomains())
+	if len(domains) == 0 {
+		return status.Error(codes.InvalidArgument, "at least one domain is required")
+	}
+	if len(domains) > s.cfg.MaxBulkDomains {
+		return status.Errorf(codes.InvalidArgument, "too many domains: %d requested, at most %d allowed", len(domains), s.cfg.MaxBulkDomains)
+	}
+
+	ctx := stream.Context()
+	var (
+		wg     sync.WaitGroup
+		sendMu sync.Mutex
+		errCh  = make(chan error, 1)
+	)
+	for _, domain := range domains {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var result *domainsearchv1.DomainAvailability
+			if name, err := domainname.Normalize(domain); err != nil {
+				result = &domainsearchv1.DomainAvailability{
+					Domain: domain,
+					Error:  status.New(codes.InvalidArgument, err.Error()).Proto(),
+				}
+			} else {
+				result = s.lookupDomain(ctx, name, req.GetCurrencyCode())
+			}
+
+			sendMu.Lock()
+			defer sendMu.Unlock()
+			if err := stream.Send(result); err != nil {
+				select {
+				case errCh <- err:
+				default:
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	select {
+	case err := <-errCh:
+		return err
+	default:
+		return nil
+	}
+}
+
+// lookupDomain checks availability and price of a domain concurrently and folds both into one result.
+func (s *SearchService) lookupDomain(ctx context.Context, domain string, currency string) *domainsearchv1.DomainAvailability {
+	result := &domainsearchv1.DomainAvailability{
+		Domain:        domain,
+		UnicodeDomain: domainname.ToUnicode(domain),
+	}
+
+	var (
+		wg              sync.WaitGroup
+		availability    = provider.AvailabilityUnknown
+		availabilityErr error
+	)
+	if s.availabilityChecker != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			availability, availabilityErr = s.availabilityChecker.CheckAvailability(ctx, domain)
+		}()
+	}
+
+	price, priceErr := s.quoteDomain(ctx, domain, currency)
+	wg.Wait()
+
+	result.AvailabilityStatus = availability.Status()
+	if price != nil {
+		provider.ApplyAvailability(price, availability)
+		result.Price = price
+	}
+
+	switch {
+	case priceErr != nil:
+		result.Error = priceErr
+	case availabilityErr != nil:
+		result.Error = status.New(codes.Unavailable, fmt.Sprintf("availability lookup: %v", availabilityErr)).Proto()
+	}
+	return result
+}
+
+// quoteDomain returns the first price streamed for the domain, or the status explaining why there is none.
+func (s *SearchService) quoteDomain(ctx context.Context, domain string, currency string) (*domainsearchv1.Price, *spb.Status) {
+	var (
+		price    *domainsearchv1.Price
+		upstream *spb.Status
+	)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	err := s.priceProvider.StreamPrices(ctx, domain, currency, func(resp *domainsearchv1.SearchPricesResponse) error {
+		if p := resp.GetPrice(); p != nil && price == nil {
+			price = p
+			cancel()
+		} else if e := resp.GetError(); e != nil && upstream == nil {
+			upstream = e
+		}
+		return nil
+	})
+	if price != nil {
+		return price, nil
+	}
+	if upstream != nil {
+		return nil, upstream
+	}
+	if err != nil {
+		return nil, status.Convert(err).Proto()
+	}
+	return nil, status.New(codes.NotFound, fmt.Sprintf("no price available for %s", domain)).Proto()
+}
+
+// uniqueDomains normalizes the requested domains and drops blanks and duplicates while preserving order.
+// Invalid names are kept as given so that they can be reported individually.
+func uniqueDomains(domains []string) []string {
+	seen := make(map[string]struct{}, len(domains))
+	out := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		if name, err := domainname.Normalize(domain); err == nil {
+			domain = name
+		} else if domain = strings.TrimSpace(domain); domain == "" {
+			continue
+		}
+		if _, ok := seen[domain]; ok {
+			continue
+		}
+		seen[domain] = struct{}{}
+		out = append(out, domain)
+	}
+	return out
+}
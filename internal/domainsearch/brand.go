@@ -0,0 +1,41 @@
+package domainsearch
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/olaysco/domain-search-llm/internal/domainname"
+	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
+)
+
+// bannedWords returns the banned words of the search, lowercased and without spaces or hyphens.
+func bannedWords(req *domainsearchv1.SearchPricesRequest) []string {
+	var words []string
+	for _, word := range req.GetBrand().GetBannedWords() {
+		if word = compactWord(word); word != "" {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// containsBannedWord returns the first banned word found in the label of a normalized domain, ignoring case
+// and hyphens, or "" when there is none.
+func containsBannedWord(domain string, banned []string) string {
+	if len(banned) == 0 {
+		return ""
+	}
+	label := compactWord(domainname.ToUnicode(suggestionLabel(domain)))
+	for _, word := range banned {
+		if strings.Contains(label, word) {
+			return word
+		}
+	}
+	return ""
+}
+
+func compactWord(word string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(word), func(r rune) bool {
+		return r == '-' || unicode.IsSpace(r)
+	}), "")
+}
@@ -0,0 +1,85 @@
+package domainsearch
+
+import (
+	"fmt"
+	"strings"
+
+	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
+	"github.com/olaysco/domain-search-llm/internal/provider"
+	"github.com/shopspring/decimal"
+)
+
+// budgetFilter enforces the max_cost, max_renewal_cost and promotion_only fields of a search on priced
+// domains.
+type budgetFilter struct {
+	maxCost        *budgetLimit
+	maxRenewalCost *budgetLimit
+	promotionOnly  bool
+}
+
+// budgetLimit is an upper bound on an amount in a given currency.
+type budgetLimit struct {
+	amount   decimal.Decimal
+	currency string
+}
+
+func newBudgetFilter(req *domainsearchv1.SearchPricesRequest) budgetFilter {
+	domain := req.GetFilter().GetDomain()
+	return budgetFilter{
+		maxCost:        newBudgetLimit(domain.GetMaxCost(), req.GetCurrencyCode()),
+		maxRenewalCost: newBudgetLimit(domain.GetMaxRenewalCost(), req.GetCurrencyCode()),
+		promotionOnly:  domain.GetPromotionOnly(),
+	}
+}
+
+// newBudgetLimit reads a limit, defaulting its currency to the one the search is quoted in.
+func newBudgetLimit(money *domainsearchv1.Money, currency string) *budgetLimit {
+	if money == nil {
+		return nil
+	}
+	if money.GetCurrencyCode() != "" {
+		currency = money.GetCurrencyCode()
+	}
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		currency = provider.DefaultCurrency
+	}
+	return &budgetLimit{amount: provider.MoneyAmount(money), currency: currency}
+}
+
+// active reports whether the search set any budget constraint.
+func (b budgetFilter) active() bool {
+	return b.maxCost != nil || b.maxRenewalCost != nil || b.promotionOnly
+}
+
+// allows reports whether a price fits the budget. A price in another currency than a limit cannot be
+// compared with it and does not fit.
+func (b budgetFilter) allows(price *domainsearchv1.Price) bool {
+	if b.promotionOnly && !price.GetPromotion() {
+		return false
+	}
+	return b.maxCost.allows(provider.CostOf(price), price.GetCurrency()) &&
+		b.maxRenewalCost.allows(provider.RenewalCostOf(price), price.GetCurrency())
+}
+
+func (l *budgetLimit) allows(amount decimal.Decimal, currency string) bool {
+	if l == nil {
+		return true
+	}
+	return strings.EqualFold(currency, l.currency) && amount.LessThanOrEqual(l.amount)
+}
+
+// describe renders the budget for the generator prompt, e.g. "registration at most 20 USD, promotions only".
+func (b budgetFilter) describe() string {
+	var parts []string
+	if b.maxCost != nil {
+		parts = append(parts, fmt.Sprintf("registration at most %s %s", b.maxCost.amount.String(), b.maxCost.currency))
+	}
+	if b.maxRenewalCost != nil {
+		parts = append(parts, fmt.Sprintf("renewal at most %s %s", b.maxRenewalCost.amount.String(), b.maxRenewalCost.currency))
+	}
+	if b.promotionOnly {
+		parts = append(parts, "promotions only")
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,102 @@
+package domainsearch
+
+import (
+	"testing"
+
+	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
+	"github.com/olaysco/domain-search-llm/internal/provider"
+	"github.com/shopspring/decimal"
+)
+
+func budgetPrice(currency, cost, renewal string, promotion bool) *domainsearchv1.Price {
+	price := &domainsearchv1.Price{Domain: "brand.com", Promotion: promotion}
+	provider.SetAmounts(price, currency, decimal.RequireFromString(cost), decimal.RequireFromString(renewal))
+	return price
+}
+
+func budgetRequest(currency string, filter *domainsearchv1.DomainPriceFilter) *domainsearchv1.SearchPricesRequest {
+	return &domainsearchv1.SearchPricesRequest{
+		CurrencyCode: currency,
+		Filter:       &domainsearchv1.PriceFilter{Product: &domainsearchv1.PriceFilter_Domain{Domain: filter}},
+	}
+}
+
+func TestBudgetFilter(t *testing.T) {
+	maxCost := &domainsearchv1.DomainPriceFilter{MaxCost: provider.NewMoney(decimal.RequireFromString("20"), "")}
+	tests := []struct {
+		name     string
+		req      *domainsearchv1.SearchPricesRequest
+		price    *domainsearchv1.Price
+		allows   bool
+		describe string
+	}{
+		{
+			name:   "no budget",
+			req:    budgetRequest("USD", nil),
+			price:  budgetPrice("USD", "500", "500", false),
+			allows: true,
+		},
+		{
+			name:     "cost at the limit",
+			req:      budgetRequest("usd", maxCost),
+			price:    budgetPrice("USD", "20", "40", false),
+			allows:   true,
+			describe: "registration at most 20 USD",
+		},
+		{
+			name:     "cost over the limit",
+			req:      budgetRequest("USD", maxCost),
+			price:    budgetPrice("USD", "20.01", "20", false),
+			describe: "registration at most 20 USD",
+		},
+		{
+			name:     "limit in another currency",
+			req:      budgetRequest("", maxCost),
+			price:    budgetPrice("EUR", "5", "5", false),
+			describe: "registration at most 20 USD",
+		},
+		{
+			name: "limit with its own currency",
+			req: budgetRequest("USD", &domainsearchv1.DomainPriceFilter{
+				MaxRenewalCost: provider.NewMoney(decimal.RequireFromString("15.5"), "eur"),
+			}),
+			price:    budgetPrice("EUR", "99", "15.5", false),
+			allows:   true,
+			describe: "renewal at most 15.5 EUR",
+		},
+		{
+			name: "renewal over the limit",
+			req: budgetRequest("USD", &domainsearchv1.DomainPriceFilter{
+				MaxCost:        provider.NewMoney(decimal.RequireFromString("20"), "USD"),
+				MaxRenewalCost: provider.NewMoney(decimal.RequireFromString("30"), "USD"),
+			}),
+			price:    budgetPrice("USD", "10", "35", false),
+			describe: "registration at most 20 USD, renewal at most 30 USD",
+		},
+		{
+			name:     "promotion only without a promotion",
+			req:      budgetRequest("USD", &domainsearchv1.DomainPriceFilter{PromotionOnly: true}),
+			price:    budgetPrice("USD", "1", "1", false),
+			describe: "promotions only",
+		},
+		{
+			name:     "promotion only with a promotion",
+			req:      budgetRequest("USD", &domainsearchv1.DomainPriceFilter{PromotionOnly: true}),
+			price:    budgetPrice("USD", "1", "1", true),
+			allows:   true,
+			describe: "promotions only",
+		},
+	}
+	for _, tt := range tests {
+		budget := newBudgetFilter(tt.req)
+		if got := budget.allows(tt.price); got != tt.allows {
+			t.Errorf("%s: allows = %v, want %v", tt.name, got, tt.allows)
+		}
+		if got := budget.active(); got != (tt.describe != "") {
+			t.Errorf("%s: active = %v, want %v", tt.name, got, tt.describe != "")
+		}
+		if got := budget.describe(); got != tt.describe {
+			t.Errorf("%s: describe = %q, want %q", tt.name, got, tt.describe)
+		}
+	}
+}
@@ -0,0 +1,314 @@
+package domainsearch
+
+import (
+	"context"
+	"sync"
+
+	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
+	"github.com/olaysco/domain-search-llm/internal/llm"
+	"github.com/olaysco/domain-search-llm/internal/provider"
+	"github.com/olaysco/domain-search-llm/internal/scoring"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// candidate is a validated suggestion whose availability and relevance are resolved, ready to be priced.
+type candidate struct {
+	domain       string
+	reasoning    string
+	rating       scoring.Rating
+	availability provider.Availability
+}
+
+// suggestionGenerator asks the model for domain suggestions.
+type suggestionGenerator func(ctx context.Context, query llm.AISuggestionRequest) ([]llm.DomainSuggestion, error)
+
+// search generates, prices and streams suggestions until the requested quantity of domains is priced within
+// the budget, or the generation rounds run out, and closes the stream with a summary. Domains that were
+// filtered out, failed to price or fell outside the budget are replaced by further rounds. A sorted search
+// holds its prices back and emits them ranked just before the summary.
+func (s *SearchService) search(ctx context.Context, req *domainsearchv1.SearchPricesRequest, query llm.AISuggestionRequest, generate suggestionGenerator, send func(*domainsearchv1.SearchPricesResponse) error) error {
+	quantity := s.requestedQuantity(req)
+	budget := newBudgetFilter(req)
+	if budget.active() {
+		if query.Context == nil {
+			query.Context = make(map[string]interface{})
+		}
+		query.Context["budget"] = budget.describe()
+	}
+	source := s.newCandidateSource(req, query, generate)
+	var ranked *rankedSender
+	if req.GetSort() != domainsearchv1.SortOrder_SORT_ORDER_UNSPECIFIED {
+		ranked = newRankedSender(req, send)
+		send = ranked.send
+	}
+
+	candidates, err := source.next(ctx, quantity)
+	if err != nil {
+		return err
+	}
+	summary := &domainsearchv1.SearchSummary{}
+	for len(candidates) > 0 {
+		if err := s.streamCandidates(ctx, req.GetCurrencyCode(), candidates, budget, summary, send); err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		missing := quantity - int(summary.GetPriced())
+		if missing <= 0 {
+			break
+		}
+		if candidates, err = source.next(ctx, missing); err != nil {
+			return err
+		}
+	}
+	if ranked != nil {
+		if err := ranked.flush(); err != nil {
+			return err
+		}
+	}
+	return send(&domainsearchv1.SearchPricesResponse{
+		Response: &domainsearchv1.SearchPricesResponse_Summary{Summary: summary},
+	})
+}
+
+// candidateSource generates candidates for one search over several rounds, never offering a domain twice.
+type candidateSource struct {
+	s        *SearchService
+	req      *domainsearchv1.SearchPricesRequest
+	query    llm.AISuggestionRequest
+	generate suggestionGenerator
+	tlds     tldFilter
+	banned   []string
+
+	seen      map[string]struct{}
+	suggested []string
+	rounds    int
+	// pending holds candidates generated beyond the count asked for, offered first by the next call.
+	pending []candidate
+}
+
+func (s *SearchService) newCandidateSource(req *domainsearchv1.SearchPricesRequest, query llm.AISuggestionRequest, generate suggestionGenerator) *candidateSource {
+	return &candidateSource{
+		s:        s,
+		req:      req,
+		query:    query,
+		generate: generate,
+		tlds:     newTLDFilter(req),
+		banned:   bannedWords(req),
+		seen:     make(map[string]struct{}),
+	}
+}
+
+// next generates suggestions until count of them survive validation, the TLD and banned word filters and
+// availability filtering, or the generation rounds run out. Every round asks only for the missing count and
+// excludes the domains suggested so far. A failure of the first round of the search is returned; a later
+// failure ends the generation with the candidates gathered so far.
+func (c *candidateSource) next(ctx context.Context, count int) ([]candidate, error) {
+	candidates := c.pending
+	c.pending = nil
+	for c.rounds < c.s.cfg.GenerationRounds && len(candidates) < count {
+		c.rounds++
+		c.query.MaxResults = c.tlds.labelsFor(count - len(candidates))
+		c.query.Exclude = c.suggested
+		suggestions, err := c.generate(ctx, c.query)
+		if err != nil {
+			if c.rounds == 1 {
+				return nil, err
+			}
+			c.s.log.Warn("top-up round failed", zap.Error(err))
+			c.rounds = c.s.cfg.GenerationRounds
+			break
+		}
+
+		fresh := make([]llm.DomainSuggestion, 0, len(suggestions))
+		for _, suggestion := range c.s.validSuggestions(c.tlds.expandSuggestions(suggestions), c.req.GetIncludeIdn()) {
+			if _, ok := c.seen[suggestion.Domain]; ok {
+				continue
+			}
+			c.seen[suggestion.Domain] = struct{}{}
+			c.suggested = append(c.suggested, suggestion.Domain)
+			if !c.tlds.allows(suggestion.Domain) {
+				c.s.log.Debug("dropping suggestion outside the requested TLDs", zap.String("domain", suggestion.Domain))
+				continue
+			}
+			if word := containsBannedWord(suggestion.Domain, c.banned); word != "" {
+				c.s.log.Debug("dropping suggestion with a banned word", zap.String("domain", suggestion.Domain), zap.String("word", word))
+				continue
+			}
+			fresh = append(fresh, suggestion)
+		}
+		candidates = append(candidates, c.s.prepareCandidates(ctx, c.req, fresh)...)
+	}
+	if len(candidates) > count {
+		candidates, c.pending = candidates[:count], candidates[count:]
+	}
+	return candidates, nil
+}
+
+// prepareCandidates checks availability and scores every suggestion concurrently. Taken domains are dropped
+// when the request excludes unavailable names. The suggestion order is preserved.
+func (s *SearchService) prepareCandidates(ctx context.Context, req *domainsearchv1.SearchPricesRequest, suggestions []llm.DomainSuggestion) []candidate {
+	prepared := make([]*candidate, len(suggestions))
+	var wg sync.WaitGroup
+	for i, suggestion := range suggestions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			availability := s.checkAvailability(ctx, suggestion.Domain)
+			if availability == provider.AvailabilityTaken && req.GetExcludeUnavailable() {
+				return
+			}
+			prepared[i] = &candidate{
+				domain:       suggestion.Domain,
+				reasoning:    suggestion.Reasoning,
+				rating:       s.scoreDomain(ctx, req, suggestion.Domain),
+				availability: availability,
+			}
+		}()
+	}
+	wg.Wait()
+
+	candidates := make([]candidate, 0, len(prepared))
+	for _, c := range prepared {
+		if c != nil {
+			candidates = append(candidates, *c)
+		}
+	}
+	return candidates
+}
+
+// streamCandidates prices the candidates and passes every response to send, tagged with its domain and
+// decorated with the candidate's scores, reasoning and availability. Prices outside the budget are left out.
+// A domain whose lookup fails is answered with an error response while the other domains carry on, and the
+// outcome of every domain is added to summary. Lookups are batched when the provider supports it. Calls to
+// send are serialized; only a failing send, meaning the caller is gone, aborts the search.
+func (s *SearchService) streamCandidates(ctx context.Context, currency string, candidates []candidate, budget budgetFilter, summary *domainsearchv1.SearchSummary, send func(*domainsearchv1.SearchPricesResponse) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	byDomain := make(map[string]candidate, len(candidates))
+	domains := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		byDomain[c.domain] = c
+		domains = append(domains, c.domain)
+	}
+
+	var (
+		mu       sync.Mutex
+		outcomes = make(map[string]domainOutcome, len(domains))
+		sendErr  error
+	)
+	relay := func(domain string, resp *domainsearchv1.SearchPricesResponse) error {
+		if resp == nil || resp.GetResponse() == nil {
+			return nil
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if sendErr != nil {
+			return sendErr
+		}
+		if price := resp.GetPrice(); price != nil {
+			if !budget.allows(price) {
+				if outcomes[domain] != outcomePriced {
+					outcomes[domain] = outcomeOverBudget
+				}
+				return nil
+			}
+			c := byDomain[domain]
+			price.SimilarityScore = c.rating.Score
+			price.SemanticSimilarity = c.rating.Semantic
+			if c.reasoning != "" {
+				price.Reasoning = c.reasoning
+			}
+			provider.ApplyAvailability(price, c.availability)
+			outcomes[domain] = outcomePriced
+		} else if outcomes[domain] == 0 {
+			outcomes[domain] = outcomeFailed
+		}
+		resp.Domain = domain
+		if err := send(resp); err != nil {
+			sendErr = err
+			cancel()
+			return err
+		}
+		return nil
+	}
+	// failUnanswered answers every domain that has no outcome yet with an error built by fail.
+	failUnanswered := func(fail func(domain string) *domainsearchv1.SearchPricesResponse) {
+		for _, domain := range domains {
+			mu.Lock()
+			_, answered := outcomes[domain]
+			mu.Unlock()
+			if !answered {
+				_ = relay(domain, fail(domain))
+			}
+		}
+	}
+
+	if batcher, ok := s.priceProvider.(provider.BatchPriceProvider); ok {
+		if err := batcher.StreamPricesBatch(ctx, domains, currency, relay); err != nil && ctx.Err() == nil {
+			s.log.Warn("batched price lookup failed", zap.Int("domains", len(domains)), zap.Error(err))
+			failUnanswered(func(string) *domainsearchv1.SearchPricesResponse { return errorResponse(status.Convert(err)) })
+		}
+	} else {
+		var wg sync.WaitGroup
+		for _, domain := range domains {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := s.priceProvider.StreamPrices(ctx, domain, currency, func(resp *domainsearchv1.SearchPricesResponse) error {
+					return relay(domain, resp)
+				})
+				if err != nil && ctx.Err() == nil {
+					s.log.Warn("price lookup failed", zap.String("domain", domain), zap.Error(err))
+					_ = relay(domain, errorResponse(status.Convert(err)))
+				}
+			}()
+		}
+		wg.Wait()
+	}
+
+	if sendErr != nil {
+		return sendErr
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	failUnanswered(func(domain string) *domainsearchv1.SearchPricesResponse {
+		return errorResponse(status.Newf(codes.NotFound, "no price found for %s", domain))
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+
+	summary.Requested += uint32(len(domains))
+	for _, outcome := range outcomes {
+		switch outcome {
+		case outcomePriced:
+			summary.Priced++
+		case outcomeFailed:
+			summary.Failed++
+		case outcomeOverBudget:
+			summary.OverBudget++
+		}
+	}
+	return nil
+}
+
+// domainOutcome records whether a domain was priced, failed or priced outside the budget during a search.
+type domainOutcome int
+
+const (
+	outcomeFailed domainOutcome = iota + 1
+	outcomeOverBudget
+	outcomePriced
+)
+
+func errorResponse(st *status.Status) *domainsearchv1.SearchPricesResponse {
+	return &domainsearchv1.SearchPricesResponse{
+		Response: &domainsearchv1.SearchPricesResponse_Error{Error: st.Proto()},
+	}
+}
@@ -0,0 +1,76 @@
+package domainsearch
+
+import (
+	"context"
+	"testing"
+
+	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
+	"github.com/olaysco/domain-search-llm/internal/llm"
+	"github.com/olaysco/domain-search-llm/internal/provider"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// stubPrices prices every domain at 10 USD, except those listed in failing, which fail upstream.
+type stubPrices struct {
+	failing map[string]bool
+}
+
+func (p stubPrices) StreamPrices(_ context.Context, domain string, _ string, handler provider.PriceStreamHandler) error {
+	if p.failing[domain] {
+		return status.Error(codes.Unavailable, "upstream down")
+	}
+	price := &domainsearchv1.Price{Domain: domain}
+	provider.SetAmounts(price, "USD", decimal.NewFromInt(10), decimal.NewFromInt(10))
+	return handler(&domainsearchv1.SearchPricesResponse{Response: &domainsearchv1.SearchPricesResponse_Price{Price: price}})
+}
+
+func TestSearchTopsUpFailedPricesWithoutBudget(t *testing.T) {
+	s := NewSearchService(nil, nil, stubPrices{failing: map[string]bool{"broken.com": true}}, nil, nil, nil, Config{GenerationRounds: 3})
+	rounds := [][]string{{"good.com", "broken.com"}, {"spare.com"}}
+	var asked []int
+	generate := func(_ context.Context, query llm.AISuggestionRequest) ([]llm.DomainSuggestion, error) {
+		asked = append(asked, query.MaxResults)
+		if len(asked) > len(rounds) {
+			return nil, nil
+		}
+		var out []llm.DomainSuggestion
+		for _, domain := range rounds[len(asked)-1] {
+			out = append(out, llm.DomainSuggestion{Domain: domain})
+		}
+		return out, nil
+	}
+
+	req := &domainsearchv1.SearchPricesRequest{
+		Query: "good",
+		Filter: &domainsearchv1.PriceFilter{Product: &domainsearchv1.PriceFilter_Domain{
+			Domain: &domainsearchv1.DomainPriceFilter{Quantity: wrapperspb.UInt32(2)},
+		}},
+	}
+	var summary *domainsearchv1.SearchSummary
+	priced := map[string]bool{}
+	err := s.search(context.Background(), req, llm.AISuggestionRequest{Query: "good"}, generate, func(resp *domainsearchv1.SearchPricesResponse) error {
+		if resp.GetPrice() != nil {
+			priced[resp.GetDomain()] = true
+		}
+		if resp.GetSummary() != nil {
+			summary = resp.GetSummary()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+
+	if !priced["good.com"] || !priced["spare.com"] || len(priced) != 2 {
+		t.Errorf("priced = %v, want good.com and spare.com", priced)
+	}
+	if len(asked) != 2 || asked[1] != 1 {
+		t.Errorf("rounds asked for %v, want a second round for the one failed domain", asked)
+	}
+	if summary.GetPriced() != 2 || summary.GetFailed() != 1 || summary.GetRequested() != 3 {
+		t.Errorf("summary = %v, want 3 requested, 2 priced and 1 failed", summary)
+	}
+}
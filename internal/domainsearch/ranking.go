@@ -0,0 +1,105 @@
+package domainsearch
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/olaysco/domain-search-llm/internal/domainname"
+	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
+	"github.com/olaysco/domain-search-llm/internal/provider"
+	"github.com/shopspring/decimal"
+)
+
+// rankedSender holds back the prices of a sorted search so that they can be emitted in ranked order once
+// the search is complete. Error answers pass through immediately.
+type rankedSender struct {
+	order    domainsearchv1.SortOrder
+	currency string
+	next     func(*domainsearchv1.SearchPricesResponse) error
+	prices   []*domainsearchv1.SearchPricesResponse
+}
+
+func newRankedSender(req *domainsearchv1.SearchPricesRequest, next func(*domainsearchv1.SearchPricesResponse) error) *rankedSender {
+	currency := strings.ToUpper(strings.TrimSpace(req.GetCurrencyCode()))
+	if currency == "" {
+		currency = provider.DefaultCurrency
+	}
+	return &rankedSender{order: req.GetSort(), currency: currency, next: next}
+}
+
+func (r *rankedSender) send(resp *domainsearchv1.SearchPricesResponse) error {
+	if resp.GetPrice() == nil {
+		return r.next(resp)
+	}
+	r.prices = append(r.prices, resp)
+	return nil
+}
+
+// flush emits the collected prices ranked from 1. Ties are broken by relevance and then by domain, so the
+// same results always get the same ranks.
+func (r *rankedSender) flush() error {
+	slices.SortStableFunc(r.prices, func(a, b *domainsearchv1.SearchPricesResponse) int {
+		if c := r.compare(a.GetPrice(), b.GetPrice()); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.GetPrice().GetSimilarityScore(), a.GetPrice().GetSimilarityScore()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.GetDomain(), b.GetDomain())
+	})
+	for i, resp := range r.prices {
+		resp.GetPrice().Rank = uint32(i + 1)
+		if err := r.next(resp); err != nil {
+			return err
+		}
+	}
+	r.prices = nil
+	return nil
+}
+
+// compare orders two prices by the sort key. Amounts are only comparable within a currency, so prices
+// quoted in the requested currency come before the others.
+func (r *rankedSender) compare(a, b *domainsearchv1.Price) int {
+	switch r.order {
+	case domainsearchv1.SortOrder_SORT_ORDER_RELEVANCE:
+		return cmp.Compare(b.GetSimilarityScore(), a.GetSimilarityScore())
+	case domainsearchv1.SortOrder_SORT_ORDER_LENGTH:
+		return cmp.Compare(labelLength(a.GetDomain()), labelLength(b.GetDomain()))
+	case domainsearchv1.SortOrder_SORT_ORDER_PRICE_ASC:
+		return r.compareAmounts(a, b, provider.CostOf)
+	case domainsearchv1.SortOrder_SORT_ORDER_RENEWAL_ASC:
+		return r.compareAmounts(a, b, provider.RenewalCostOf)
+	case domainsearchv1.SortOrder_SORT_ORDER_THREE_YEAR_COST:
+		return r.compareAmounts(a, b, threeYearCost)
+	default:
+		return 0
+	}
+}
+
+func (r *rankedSender) compareAmounts(a, b *domainsearchv1.Price, amount func(*domainsearchv1.Price) decimal.Decimal) int {
+	aRequested := strings.EqualFold(a.GetCurrency(), r.currency)
+	bRequested := strings.EqualFold(b.GetCurrency(), r.currency)
+	switch {
+	case aRequested && !bRequested:
+		return -1
+	case !aRequested && bRequested:
+		return 1
+	}
+	if c := strings.Compare(a.GetCurrency(), b.GetCurrency()); c != 0 {
+		return c
+	}
+	return amount(a).Cmp(amount(b))
+}
+
+// threeYearCost is the cost of registering a domain and renewing it twice.
+func threeYearCost(price *domainsearchv1.Price) decimal.Decimal {
+	return provider.CostOf(price).Add(provider.RenewalCostOf(price).Mul(decimal.NewFromInt(2)))
+}
+
+// labelLength is the number of characters of a domain's name without its TLD, counting an
+// internationalized name in its Unicode form.
+func labelLength(domain string) int {
+	return utf8.RuneCountInString(domainname.ToUnicode(suggestionLabel(domain)))
+}
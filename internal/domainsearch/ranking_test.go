@@ -0,0 +1,82 @@
+package domainsearch
+
+import (
+	"strings"
+	"testing"
+
+	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
+	"github.com/olaysco/domain-search-llm/internal/provider"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func rankedPrice(domain, currency string, cost, renewal int64, similarity float64) *domainsearchv1.SearchPricesResponse {
+	price := &domainsearchv1.Price{Domain: domain, SimilarityScore: similarity}
+	provider.SetAmounts(price, currency, decimal.NewFromInt(cost), decimal.NewFromInt(renewal))
+	return &domainsearchv1.SearchPricesResponse{
+		Domain:   domain,
+		Response: &domainsearchv1.SearchPricesResponse_Price{Price: price},
+	}
+}
+
+func TestRankedSender(t *testing.T) {
+	tests := []struct {
+		order domainsearchv1.SortOrder
+		want  string
+	}{
+		// Equal costs fall back to relevance, then to the domain name.
+		{domainsearchv1.SortOrder_SORT_ORDER_PRICE_ASC, "brandly.io tiny.io bücher.de zeta.io longbrand.com eurobrand.eu"},
+		{domainsearchv1.SortOrder_SORT_ORDER_RENEWAL_ASC, "zeta.io brandly.io longbrand.com bücher.de tiny.io eurobrand.eu"},
+		{domainsearchv1.SortOrder_SORT_ORDER_THREE_YEAR_COST, "brandly.io zeta.io longbrand.com bücher.de tiny.io eurobrand.eu"},
+		{domainsearchv1.SortOrder_SORT_ORDER_RELEVANCE, "tiny.io brandly.io longbrand.com eurobrand.eu bücher.de zeta.io"},
+		// bücher counts its six characters rather than the thirteen of its A-label.
+		{domainsearchv1.SortOrder_SORT_ORDER_LENGTH, "tiny.io zeta.io bücher.de brandly.io longbrand.com eurobrand.eu"},
+	}
+	for _, tt := range tests {
+		var sent []string
+		ranks := make(map[string]uint32)
+		r := newRankedSender(&domainsearchv1.SearchPricesRequest{CurrencyCode: "usd", Sort: tt.order}, func(resp *domainsearchv1.SearchPricesResponse) error {
+			if price := resp.GetPrice(); price != nil {
+				sent = append(sent, price.GetUnicodeDomain())
+				ranks[price.GetUnicodeDomain()] = price.GetRank()
+			} else {
+				sent = append(sent, "error")
+			}
+			return nil
+		})
+
+		responses := []*domainsearchv1.SearchPricesResponse{
+			rankedPrice("longbrand.com", "USD", 12, 12, 0.7),
+			rankedPrice("eurobrand.eu", "EUR", 1, 1, 0.5),
+			rankedPrice("zeta.io", "USD", 10, 5, 0.3),
+			rankedPrice("xn--bcher-kva.de", "USD", 10, 20, 0.5),
+			{Domain: "taken.com", Response: &domainsearchv1.SearchPricesResponse_Error{Error: status.New(codes.NotFound, "taken").Proto()}},
+			rankedPrice("tiny.io", "USD", 10, 30, 0.9),
+			rankedPrice("brandly.io", "USD", 5, 7, 0.8),
+		}
+		for _, resp := range responses {
+			if price := resp.GetPrice(); price != nil {
+				price.UnicodeDomain = strings.Replace(price.GetDomain(), "xn--bcher-kva", "bücher", 1)
+			}
+			if err := r.send(resp); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if len(sent) != 1 || sent[0] != "error" {
+			t.Fatalf("%s: sent before flush = %v, want only the error answer", tt.order, sent)
+		}
+		if err := r.flush(); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := strings.Join(sent[1:], " "); got != tt.want {
+			t.Errorf("%s: order = %s, want %s", tt.order, got, tt.want)
+		}
+		for i, domain := range sent[1:] {
+			if ranks[domain] != uint32(i+1) {
+				t.Errorf("%s: %s has rank %d, want %d", tt.order, domain, ranks[domain], i+1)
+			}
+		}
+	}
+}
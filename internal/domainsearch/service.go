@@ -2,36 +2,74 @@ package domainsearch
 
 import (
 	"context"
-	"errors"
-	"fmt"
 	"math/rand"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/olaysco/domain-search-llm/internal/domainname"
 	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
 	"github.com/olaysco/domain-search-llm/internal/llm"
 	"github.com/olaysco/domain-search-llm/internal/provider"
+	"github.com/olaysco/domain-search-llm/internal/scoring"
+	"go.uber.org/zap"
+)
+
+// Config holds the tunables of the search service.
+type Config struct {
+	// MaxBulkDomains caps the number of domains accepted by BulkCheckAvailability.
+	MaxBulkDomains int
+	// MaxResults caps the number of suggestions a search may ask for with DomainPriceFilter.quantity.
+	MaxResults int
+	// GenerationRounds bounds how often the model is asked for suggestions when validation and availability
+	// filtering leave fewer candidates than requested.
+	GenerationRounds int
+}
+
+const (
+	defaultResults          = 10
+	defaultMaxResults       = 50
+	defaultGenerationRounds = 3
 )
 
 // Service implements the DomainSearchServiceServer generated by protoc.
 type SearchService struct {
 	domainsearchv1.UnimplementedDomainSearchServiceServer
-	llmSuggester  *llm.LLMSuggester
-	llmAgent      *llm.LLMAgent
-	priceProvider provider.PriceProvider
+	llmSuggester        *llm.LLMSuggester
+	llmAgent            *llm.LLMAgent
+	priceProvider       provider.PriceProvider
+	availabilityChecker provider.AvailabilityChecker
+	scorer              *scoring.Scorer
+	log                 *zap.Logger
+	cfg                 Config
 
 	rnd  *rand.Rand
 	lock sync.Mutex
 }
 
-// NewService constructs a Service with a time-based random seed.
-func NewSearchService(llmSusggester *llm.LLMSuggester, llmAgent *llm.LLMAgent, priceProvider provider.PriceProvider) *SearchService {
+// NewService constructs a Service with a time-based random seed. A nil log discards the service's logs.
+func NewSearchService(llmSusggester *llm.LLMSuggester, llmAgent *llm.LLMAgent, priceProvider provider.PriceProvider, availabilityChecker provider.AvailabilityChecker, scorer *scoring.Scorer, log *zap.Logger, cfg Config) *SearchService {
+	if log == nil {
+		log = zap.NewNop()
+	}
+	if cfg.MaxBulkDomains <= 0 {
+		cfg.MaxBulkDomains = defaultMaxBulkDomains
+	}
+	if cfg.MaxResults <= 0 {
+		cfg.MaxResults = defaultMaxResults
+	}
+	if cfg.GenerationRounds <= 0 {
+		cfg.GenerationRounds = defaultGenerationRounds
+	}
 	return &SearchService{
-		rnd:           rand.New(rand.NewSource(time.Now().UnixNano())),
-		llmSuggester:  llmSusggester,
-		llmAgent:      llmAgent,
-		priceProvider: priceProvider,
+		cfg:                 cfg,
+		rnd:                 rand.New(rand.NewSource(time.Now().UnixNano())),
+		llmSuggester:        llmSusggester,
+		llmAgent:            llmAgent,
+		priceProvider:       priceProvider,
+		availabilityChecker: availabilityChecker,
+		scorer:              scorer,
+		log:                 log,
 	}
 }
 
@@ -45,50 +83,16 @@ func (s *SearchService) CheckPrice(req *domainsearchv1.SearchPricesRequest, stre
 	}
 
 	llmQuery := llm.AISuggestionRequest{
-		Query:      req.Query,
-		MaxResults: 10,
-		Context:    buildLLMContext(req),
+		Query:    req.Query,
+		Context:  buildLLMContext(req),
+		IDN:      req.GetIncludeIdn(),
+		Currency: req.GetCurrencyCode(),
 	}
-	llmResponse, err := s.llmSuggester.GenerateDomainSuggestions(ctx, llmQuery)
-	if err != nil {
-		fmt.Println(err)
+	if err := s.search(ctx, req, llmQuery, s.llmSuggester.GenerateDomainSuggestions, stream.Send); err != nil {
+		s.log.Warn("search failed", zap.Error(err))
 		return err
 	}
-
-	var wg sync.WaitGroup
-	errCh := make(chan error, 1)
-
-	for _, suggestion := range llmResponse {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			if err := s.priceProvider.StreamPrices(ctx, suggestion.Domain, func(resp *domainsearchv1.SearchPricesResponse) error {
-				if resp == nil {
-					return nil
-				}
-				if price := resp.GetPrice(); price != nil {
-					price.SimilarityScore = suggestion.Score
-					fmt.Println(price)
-				}
-				return stream.Send(resp)
-			}); err != nil && !errors.Is(err, context.Canceled) {
-				select {
-				case errCh <- err:
-				default:
-				}
-				cancel()
-			}
-		}()
-	}
-
-	wg.Wait()
-	select {
-	case err := <-errCh:
-		fmt.Println(err)
-		return err
-	default:
-		return nil
-	}
+	return nil
 }
 
 func (s *SearchService) CheckPriceAgent(req *domainsearchv1.SearchPricesRequest, stream domainsearchv1.DomainSearchService_CheckPriceAgentServer) error {
@@ -100,54 +104,88 @@ func (s *SearchService) CheckPriceAgent(req *domainsearchv1.SearchPricesRequest,
 	}
 
 	llmQuery := llm.AISuggestionRequest{
-		Query:      req.Query,
-		MaxResults: 10,
-		Context:    buildLLMContext(req),
+		Query:    req.Query,
+		Context:  buildLLMContext(req),
+		IDN:      req.GetIncludeIdn(),
+		Currency: req.GetCurrencyCode(),
 	}
 
 	// Execute agent to get domain suggestions
-	agentResp, err := s.llmAgent.ExecuteWithTools(ctx, llmQuery)
-	if err != nil {
-		fmt.Println(err)
+	generate := func(ctx context.Context, query llm.AISuggestionRequest) ([]llm.DomainSuggestion, error) {
+		agentResp, err := s.llmAgent.ExecuteWithTools(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		return agentResp.Domains, nil
+	}
+
+	// Stream prices for each domain suggestion (cache is handled by the provider)
+	if err := s.search(ctx, req, llmQuery, generate, stream.Send); err != nil {
+		s.log.Warn("search failed", zap.Error(err))
 		return err
 	}
+	return nil
+}
 
-	// Stream prices for each domain suggestion
-	var wg sync.WaitGroup
-	errCh := make(chan error, 1)
-
-	for _, suggestion := range agentResp.Domains {
-		wg.Add(1)
-		go func(domain llm.DomainSuggestion) {
-			defer wg.Done()
-			// Fetch prices from provider (cache is handled internally)
-			if err := s.priceProvider.StreamPrices(ctx, domain.Domain, func(resp *domainsearchv1.SearchPricesResponse) error {
-				if resp == nil {
-					return nil
-				}
-				if price := resp.GetPrice(); price != nil {
-					price.SimilarityScore = domain.Score
-					price.Reasoning = domain.Reasoning
-				}
-				return stream.Send(resp)
-			}); err != nil && !errors.Is(err, context.Canceled) {
-				select {
-				case errCh <- err:
-				default:
-				}
-				cancel()
-			}
-		}(suggestion)
+// requestedQuantity is the number of suggestions the search asked for with DomainPriceFilter.quantity,
+// bounded by the configured maximum.
+func (s *SearchService) requestedQuantity(req *domainsearchv1.SearchPricesRequest) int {
+	quantity := int(req.GetFilter().GetDomain().GetQuantity().GetValue())
+	if quantity <= 0 {
+		quantity = defaultResults
 	}
+	return min(quantity, s.cfg.MaxResults)
+}
 
-	wg.Wait()
-	select {
-	case err := <-errCh:
-		fmt.Println(err)
-		return err
-	default:
-		return nil
+// checkAvailability resolves the availability of a domain, treating checker failures as unknown so that a
+// flaky registry never hides or misreports an otherwise valid suggestion.
+func (s *SearchService) checkAvailability(ctx context.Context, domain string) provider.Availability {
+	if s.availabilityChecker == nil {
+		return provider.AvailabilityUnknown
+	}
+	availability, err := s.availabilityChecker.CheckAvailability(ctx, domain)
+	if err != nil {
+		s.log.Debug("availability check failed", zap.String("domain", domain), zap.Error(err))
+		return provider.AvailabilityUnknown
+	}
+	return availability
+}
+
+// validSuggestions normalizes the generated domains and drops duplicates and names that cannot be
+// registered, so that only well-formed domains reach the availability and price lookups. Internationalized
+// names are converted to A-labels and dropped entirely unless the caller opted into them.
+func (s *SearchService) validSuggestions(suggestions []llm.DomainSuggestion, allowIDN bool) []llm.DomainSuggestion {
+	seen := make(map[string]struct{}, len(suggestions))
+	out := make([]llm.DomainSuggestion, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		name, err := domainname.Normalize(suggestion.Domain)
+		if err != nil {
+			s.log.Debug("dropping suggestion", zap.String("domain", suggestion.Domain), zap.Error(err))
+			continue
+		}
+		if !allowIDN && domainname.IsIDN(name) {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		suggestion.Domain = name
+		out = append(out, suggestion)
+	}
+	return out
+}
+
+// scoreDomain rates a suggestion against the search query, honouring the requested TLDs.
+func (s *SearchService) scoreDomain(ctx context.Context, req *domainsearchv1.SearchPricesRequest, domain string) scoring.Rating {
+	if s.scorer == nil {
+		return scoring.Rating{}
 	}
+	return s.scorer.Rate(ctx, scoring.Candidate{
+		Query:         req.GetQuery(),
+		Domain:        domain,
+		PreferredTLDs: splitTLDList(req.GetFilter().GetDomain().GetIncludedTldNames()),
+	})
 }
 
 func buildLLMContext(req *domainsearchv1.SearchPricesRequest) map[string]interface{} {
@@ -165,8 +203,36 @@ func buildLLMContext(req *domainsearchv1.SearchPricesRequest) map[string]interfa
 			}
 		}
 	}
+	if brand := req.GetBrand(); brand != nil {
+		setContextString(ctx, "business_type", brand.GetBusinessType())
+		setContextString(ctx, "location", brand.GetLocation())
+		setContextString(ctx, "brand_keywords", joinWords(brand.GetBrandKeywords()))
+		if tone := brand.GetTone(); tone != domainsearchv1.Tone_TONE_UNSPECIFIED {
+			ctx["tone"] = strings.ToLower(strings.TrimPrefix(tone.String(), "TONE_"))
+		}
+		setContextString(ctx, "banned_words", joinWords(brand.GetBannedWords()))
+		setContextString(ctx, "target_audience", brand.GetTargetAudience())
+	}
 	if len(ctx) == 0 {
 		return nil
 	}
 	return ctx
 }
+
+// setContextString stores a trimmed value under key unless it is empty.
+func setContextString(ctx map[string]interface{}, key, value string) {
+	if value = strings.TrimSpace(value); value != "" {
+		ctx[key] = value
+	}
+}
+
+// joinWords renders a word list for the prompt, skipping blank entries.
+func joinWords(words []string) string {
+	kept := make([]string, 0, len(words))
+	for _, word := range words {
+		if word = strings.TrimSpace(word); word != "" {
+			kept = append(kept, word)
+		}
+	}
+	return strings.Join(kept, ", ")
+}
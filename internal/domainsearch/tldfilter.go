@@ -0,0 +1,123 @@
+package domainsearch
+
+import (
+	"strings"
+
+	"github.com/olaysco/domain-search-llm/internal/domainname"
+	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
+	"github.com/olaysco/domain-search-llm/internal/llm"
+	"golang.org/x/net/publicsuffix"
+)
+
+// tldFilter enforces the includedTldNames or excludedTldNames of a search on the suggested domains, which
+// the model only receives as a hint.
+type tldFilter struct {
+	included []string
+	excluded map[string]struct{}
+	// expand replaces the TLD chosen by the model with every included TLD.
+	expand bool
+}
+
+func newTLDFilter(req *domainsearchv1.SearchPricesRequest) tldFilter {
+	domain := req.GetFilter().GetDomain()
+	f := tldFilter{
+		included: splitTLDList(domain.GetIncludedTldNames()),
+		excluded: make(map[string]struct{}),
+	}
+	for _, tld := range splitTLDList(domain.GetExcludedTldNames()) {
+		f.excluded[tld] = struct{}{}
+	}
+	f.expand = req.GetExpandTlds() && len(f.included) > 0
+	return f
+}
+
+// allows reports whether the public suffix of a normalized domain passes the filter.
+func (f tldFilter) allows(domain string) bool {
+	suffix, _ := publicsuffix.PublicSuffix(domain)
+	if _, ok := f.excluded[suffix]; ok {
+		return false
+	}
+	if len(f.included) == 0 {
+		return true
+	}
+	for _, tld := range f.included {
+		if tld == suffix {
+			return true
+		}
+	}
+	return false
+}
+
+// expandSuggestions pairs the label of every suggestion, or a bare label the model returned without a TLD,
+// with each included TLD. The relevance score and reasoning are carried over to every expanded name.
+func (f tldFilter) expandSuggestions(suggestions []llm.DomainSuggestion) []llm.DomainSuggestion {
+	if !f.expand {
+		return suggestions
+	}
+	seen := make(map[string]struct{}, len(suggestions))
+	out := make([]llm.DomainSuggestion, 0, len(suggestions)*len(f.included))
+	for _, suggestion := range suggestions {
+		label := suggestionLabel(suggestion.Domain)
+		if label == "" {
+			continue
+		}
+		if _, ok := seen[label]; ok {
+			continue
+		}
+		seen[label] = struct{}{}
+		for _, tld := range f.included {
+			// Tool results of the agent describe the original domain only.
+			out = append(out, llm.DomainSuggestion{
+				Domain:    label + "." + tld,
+				Score:     suggestion.Score,
+				Reasoning: suggestion.Reasoning,
+			})
+		}
+	}
+	return out
+}
+
+// labelsFor is the number of suggestions to ask the model for so that expansion yields at least count
+// domains.
+func (f tldFilter) labelsFor(count int) int {
+	if !f.expand {
+		return count
+	}
+	return (count + len(f.included) - 1) / len(f.included)
+}
+
+// suggestionLabel returns the A-label directly under the public suffix of a suggested domain ("b" for
+// "a.b.co.uk"), or the suggestion itself when the model returned a bare label. It returns "" when no label
+// can be read.
+func suggestionLabel(raw string) string {
+	name := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(raw)), ".")
+	if ascii, err := domainname.ToASCII(name); err == nil {
+		name = ascii
+	}
+	if !strings.Contains(name, ".") {
+		return name
+	}
+	registered, err := publicsuffix.EffectiveTLDPlusOne(name)
+	if err != nil {
+		return ""
+	}
+	label, _, _ := strings.Cut(registered, ".")
+	return label
+}
+
+// splitTLDList parses a comma separated TLD list such as "com,  co.uk". Entries are lowercased, stripped of
+// a leading dot and converted to their A-label form, matching normalized domains.
+func splitTLDList(list string) []string {
+	var tlds []string
+	for _, tld := range strings.Split(list, ",") {
+		tld = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(tld)), ".")
+		if tld == "" {
+			continue
+		}
+		if ascii, err := domainname.ToASCII(tld); err == nil {
+			tld = ascii
+		}
+		tlds = append(tlds, tld)
+	}
+	return tlds
+}
@@ -0,0 +1,22 @@
+package domainsearch
+
+import "testing"
+
+func TestSuggestionLabel(t *testing.T) {
+	tests := map[string]string{
+		"brand.com":        "brand",
+		"Brand.COM":        "brand",
+		"shop.brand.com":   "brand",
+		"a.b.brand.co.uk":  "brand",
+		"brand.co.uk":      "brand",
+		"xn--bcher-kva.de": "xn--bcher-kva",
+		"brand":            "brand",
+		"co.uk":            "",
+		"":                 "",
+	}
+	for raw, want := range tests {
+		if got := suggestionLabel(raw); got != want {
+			t.Errorf("suggestionLabel(%q) = %q, want %q", raw, got, want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// CachedEmbedder memoizes vectors in memory so repeated queries and labels are embedded once. When the cache
+// holds maxEntries vectors the oldest entry is evicted.
+type CachedEmbedder struct {
+	next       Embedder
+	maxEntries int
+
+	mu      sync.Mutex
+	vectors map[string][]float32
+	order   []string
+}
+
+// NewCachedEmbedder wraps next with an in-memory cache of at most maxEntries vectors.
+func NewCachedEmbedder(next Embedder, maxEntries int) *CachedEmbedder {
+	if maxEntries <= 0 {
+		maxEntries = 10000
+	}
+	return &CachedEmbedder{
+		next:       next,
+		maxEntries: maxEntries,
+		vectors:    make(map[string][]float32),
+	}
+}
+
+// CreateEmbedding implements Embedder, only forwarding the texts that are not cached yet.
+func (c *CachedEmbedder) CreateEmbedding(ctx context.Context, texts []string) ([][]float32, error) {
+	out := make([][]float32, len(texts))
+	var missing []string
+	var missingIdx []int
+
+	c.mu.Lock()
+	for i, text := range texts {
+		if vector, ok := c.vectors[text]; ok {
+			out[i] = vector
+			continue
+		}
+		missing = append(missing, text)
+		missingIdx = append(missingIdx, i)
+	}
+	c.mu.Unlock()
+
+	if len(missing) == 0 {
+		return out, nil
+	}
+
+	vectors, err := c.next.CreateEmbedding(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(missing) {
+		return nil, fmt.Errorf("embedder returned %d vectors for %d texts", len(vectors), len(missing))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, vector := range vectors {
+		out[missingIdx[i]] = vector
+		c.store(missing[i], vector)
+	}
+	return out, nil
+}
+
+func (c *CachedEmbedder) store(text string, vector []float32) {
+	if _, ok := c.vectors[text]; ok {
+		return
+	}
+	if len(c.order) >= c.maxEntries {
+		oldest := c.order[0]
+		c.order = c.order[1:]
+		delete(c.vectors, oldest)
+	}
+	c.vectors[text] = vector
+	c.order = append(c.order, text)
+}
@@ -0,0 +1,80 @@
+package embedding
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// countingEmbedder records the texts forwarded to the wrapped embedder.
+type countingEmbedder struct {
+	next  Embedder
+	calls [][]string
+	err   error
+}
+
+func (c *countingEmbedder) CreateEmbedding(ctx context.Context, texts []string) ([][]float32, error) {
+	c.calls = append(c.calls, append([]string(nil), texts...))
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.next.CreateEmbedding(ctx, texts)
+}
+
+func TestCachedEmbedderForwardsMissingTexts(t *testing.T) {
+	ctx := context.Background()
+	fake := NewFakeEmbedder(0)
+	counting := &countingEmbedder{next: fake}
+	cache := NewCachedEmbedder(counting, 0)
+
+	if _, err := cache.CreateEmbedding(ctx, []string{"coffee", "tea"}); err != nil {
+		t.Fatalf("CreateEmbedding: %v", err)
+	}
+	got, err := cache.CreateEmbedding(ctx, []string{"tea", "juice", "coffee"})
+	if err != nil {
+		t.Fatalf("CreateEmbedding: %v", err)
+	}
+
+	want := [][]string{{"coffee", "tea"}, {"juice"}}
+	if !reflect.DeepEqual(counting.calls, want) {
+		t.Errorf("forwarded %v, want %v", counting.calls, want)
+	}
+	expected, _ := fake.CreateEmbedding(ctx, []string{"tea", "juice", "coffee"})
+	if !reflect.DeepEqual(got, expected) {
+		t.Error("cached vectors are not returned in the order of the texts")
+	}
+}
+
+func TestCachedEmbedderEvictsOldest(t *testing.T) {
+	ctx := context.Background()
+	counting := &countingEmbedder{next: NewFakeEmbedder(0)}
+	cache := NewCachedEmbedder(counting, 2)
+
+	for _, text := range []string{"a", "b", "c", "b", "a"} {
+		if _, err := cache.CreateEmbedding(ctx, []string{text}); err != nil {
+			t.Fatalf("CreateEmbedding: %v", err)
+		}
+	}
+	want := [][]string{{"a"}, {"b"}, {"c"}, {"a"}}
+	if !reflect.DeepEqual(counting.calls, want) {
+		t.Errorf("forwarded %v, want %v", counting.calls, want)
+	}
+}
+
+func TestCachedEmbedderDoesNotCacheFailures(t *testing.T) {
+	ctx := context.Background()
+	counting := &countingEmbedder{next: NewFakeEmbedder(0), err: errors.New("quota exceeded")}
+	cache := NewCachedEmbedder(counting, 0)
+
+	if _, err := cache.CreateEmbedding(ctx, []string{"coffee"}); err == nil {
+		t.Fatal("expected the embedder error")
+	}
+	counting.err = nil
+	if _, err := cache.CreateEmbedding(ctx, []string{"coffee"}); err != nil {
+		t.Fatalf("CreateEmbedding: %v", err)
+	}
+	if len(counting.calls) != 2 {
+		t.Errorf("forwarded %d calls, want 2", len(counting.calls))
+	}
+}
@@ -0,0 +1,55 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/llms/ollama"
+	"github.com/tmc/langchaingo/llms/openai"
+)
+
+// Supported values for Config.Provider.
+const (
+	ProviderOpenAI = "openai"
+	ProviderOllama = "ollama"
+	ProviderFake   = "fake"
+)
+
+// Embedder turns texts into vectors. The langchaingo OpenAI and Ollama clients satisfy it.
+type Embedder interface {
+	CreateEmbedding(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// Config selects the embeddings backend.
+type Config struct {
+	Provider string // "openai" (any OpenAI-compatible /embeddings endpoint), "ollama" or "fake"
+	Endpoint string
+	APIKey   string
+	Model    string // e.g. "text-embedding-3-small", "nomic-embed-text"
+}
+
+// New builds the Embedder selected by cfg.Provider.
+func New(cfg Config) (Embedder, error) {
+	switch provider := strings.ToLower(strings.TrimSpace(cfg.Provider)); provider {
+	case ProviderOpenAI:
+		opts := []openai.Option{openai.WithToken(cfg.APIKey)}
+		if cfg.Model != "" {
+			opts = append(opts, openai.WithEmbeddingModel(cfg.Model))
+		}
+		if cfg.Endpoint != "" {
+			opts = append(opts, openai.WithBaseURL(cfg.Endpoint))
+		}
+		return openai.New(opts...)
+	case ProviderOllama:
+		opts := []ollama.Option{ollama.WithModel(cfg.Model)}
+		if cfg.Endpoint != "" {
+			opts = append(opts, ollama.WithServerURL(cfg.Endpoint))
+		}
+		return ollama.New(opts...)
+	case ProviderFake:
+		return NewFakeEmbedder(256), nil
+	default:
+		return nil, fmt.Errorf("unsupported embedding provider %q", provider)
+	}
+}
@@ -0,0 +1,54 @@
+package embedding
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"strings"
+)
+
+// FakeEmbedder is a deterministic, offline Embedder that hashes character trigrams into a fixed number of
+// buckets. Texts sharing substrings end up close to each other, which is enough for tests and local runs.
+type FakeEmbedder struct {
+	dimensions int
+}
+
+// NewFakeEmbedder returns a FakeEmbedder producing vectors of the given size.
+func NewFakeEmbedder(dimensions int) *FakeEmbedder {
+	if dimensions <= 0 {
+		dimensions = 256
+	}
+	return &FakeEmbedder{dimensions: dimensions}
+}
+
+// CreateEmbedding implements Embedder.
+func (f *FakeEmbedder) CreateEmbedding(_ context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = f.embed(text)
+	}
+	return vectors, nil
+}
+
+func (f *FakeEmbedder) embed(text string) []float32 {
+	vector := make([]float32, f.dimensions)
+	runes := []rune(" " + strings.ToLower(strings.TrimSpace(text)) + " ")
+	for i := 0; i+3 <= len(runes); i++ {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(string(runes[i : i+3])))
+		vector[h.Sum32()%uint32(f.dimensions)]++
+	}
+
+	var norm float64
+	for _, v := range vector {
+		norm += float64(v) * float64(v)
+	}
+	if norm == 0 {
+		return vector
+	}
+	norm = math.Sqrt(norm)
+	for i := range vector {
+		vector[i] = float32(float64(vector[i]) / norm)
+	}
+	return vector
+}
@@ -0,0 +1,60 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Similarity rates query/label pairs by the cosine similarity of their embeddings. It implements
+// scoring.SemanticSimilarity.
+type Similarity struct {
+	embedder Embedder
+}
+
+// NewSimilarity builds a Similarity on top of the given Embedder.
+func NewSimilarity(embedder Embedder) *Similarity {
+	return &Similarity{embedder: embedder}
+}
+
+// Similarity returns the cosine similarity between the query and the label, clamped to [0, 1].
+// Hyphens in the label are read as word separators.
+func (s *Similarity) Similarity(ctx context.Context, query, label string) (float64, error) {
+	query = strings.TrimSpace(query)
+	label = strings.ReplaceAll(strings.TrimSpace(label), "-", " ")
+	if query == "" || label == "" {
+		return 0, fmt.Errorf("query and label are required")
+	}
+
+	vectors, err := s.embedder.CreateEmbedding(ctx, []string{query, label})
+	if err != nil {
+		return 0, fmt.Errorf("create embedding: %w", err)
+	}
+	if len(vectors) != 2 {
+		return 0, fmt.Errorf("embedder returned %d vectors, want 2", len(vectors))
+	}
+
+	cos := Cosine(vectors[0], vectors[1])
+	if cos < 0 {
+		return 0, nil
+	}
+	return cos, nil
+}
+
+// Cosine returns the cosine similarity of two vectors, or 0 when they differ in length or are empty.
+func Cosine(a, b []float32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
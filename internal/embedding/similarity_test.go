@@ -0,0 +1,62 @@
+package embedding
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+func TestCosine(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []float32
+		want float64
+	}{
+		{name: "identical", a: []float32{1, 2, 3}, b: []float32{1, 2, 3}, want: 1},
+		{name: "scaled", a: []float32{1, 2, 3}, b: []float32{2, 4, 6}, want: 1},
+		{name: "orthogonal", a: []float32{1, 0}, b: []float32{0, 1}, want: 0},
+		{name: "opposite", a: []float32{1, 0}, b: []float32{-1, 0}, want: -1},
+		{name: "length mismatch", a: []float32{1, 0}, b: []float32{1, 0, 0}, want: 0},
+		{name: "zero vector", a: []float32{0, 0}, b: []float32{1, 0}, want: 0},
+		{name: "empty", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Cosine(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Cosine = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimilarityWithFakeEmbedder(t *testing.T) {
+	ctx := context.Background()
+	s := NewSimilarity(NewFakeEmbedder(0))
+
+	same, err := s.Similarity(ctx, "coffee shop", "coffee-shop")
+	if err != nil {
+		t.Fatalf("Similarity: %v", err)
+	}
+	if math.Abs(same-1) > 1e-6 {
+		t.Errorf("hyphenated label should read as the query, got %v", same)
+	}
+
+	near, err := s.Similarity(ctx, "coffee shop", "coffeehouse")
+	if err != nil {
+		t.Fatalf("Similarity: %v", err)
+	}
+	far, err := s.Similarity(ctx, "coffee shop", "xylophone")
+	if err != nil {
+		t.Fatalf("Similarity: %v", err)
+	}
+	if near <= far {
+		t.Errorf("coffeehouse (%v) should be closer to the query than xylophone (%v)", near, far)
+	}
+	if far < 0 || near > 1 {
+		t.Errorf("similarities out of [0, 1]: %v, %v", far, near)
+	}
+
+	if _, err := s.Similarity(ctx, "coffee", " "); err == nil {
+		t.Error("an empty label should fail")
+	}
+}
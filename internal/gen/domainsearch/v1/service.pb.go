@@ -10,6 +10,7 @@ import (
 	status "google.golang.org/genproto/googleapis/rpc/status"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
 	reflect "reflect"
 	sync "sync"
@@ -23,6 +24,177 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// SortOrder ranks the prices of a search.
+type SortOrder int32
+
+const (
+	// Prices are streamed as they arrive and are not ranked.
+	SortOrder_SORT_ORDER_UNSPECIFIED SortOrder = 0
+	// Highest similarity score first.
+	SortOrder_SORT_ORDER_RELEVANCE SortOrder = 1
+	// Cheapest registration first.
+	SortOrder_SORT_ORDER_PRICE_ASC SortOrder = 2
+	// Cheapest renewal first.
+	SortOrder_SORT_ORDER_RENEWAL_ASC SortOrder = 3
+	// Shortest name first, not counting the TLD.
+	SortOrder_SORT_ORDER_LENGTH SortOrder = 4
+	// Cheapest total of registration plus two renewals first.
+	SortOrder_SORT_ORDER_THREE_YEAR_COST SortOrder = 5
+)
+
+// Enum value maps for SortOrder.
+var (
+	SortOrder_name = map[int32]string{
+		0: "SORT_ORDER_UNSPECIFIED",
+		1: "SORT_ORDER_RELEVANCE",
+		2: "SORT_ORDER_PRICE_ASC",
+		3: "SORT_ORDER_RENEWAL_ASC",
+		4: "SORT_ORDER_LENGTH",
+		5: "SORT_ORDER_THREE_YEAR_COST",
+	}
+	SortOrder_value = map[string]int32{
+		"SORT_ORDER_UNSPECIFIED":     0,
+		"SORT_ORDER_RELEVANCE":       1,
+		"SORT_ORDER_PRICE_ASC":       2,
+		"SORT_ORDER_RENEWAL_ASC":     3,
+		"SORT_ORDER_LENGTH":          4,
+		"SORT_ORDER_THREE_YEAR_COST": 5,
+	}
+)
+
+func (x SortOrder) Enum() *SortOrder {
+	p := new(SortOrder)
+	*p = x
+	return p
+}
+
+func (x SortOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_domainsearch_v1_service_proto_enumTypes[0].Descriptor()
+}
+
+func (SortOrder) Type() protoreflect.EnumType {
+	return &file_domainsearch_v1_service_proto_enumTypes[0]
+}
+
+func (x SortOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortOrder.Descriptor instead.
+func (SortOrder) EnumDescriptor() ([]byte, []int) {
+	return file_domainsearch_v1_service_proto_rawDescGZIP(), []int{0}
+}
+
+// Tone is the voice of the suggested names.
+type Tone int32
+
+const (
+	Tone_TONE_UNSPECIFIED Tone = 0
+	Tone_TONE_PLAYFUL     Tone = 1
+	Tone_TONE_CORPORATE   Tone = 2
+	Tone_TONE_TECHNICAL   Tone = 3
+)
+
+// Enum value maps for Tone.
+var (
+	Tone_name = map[int32]string{
+		0: "TONE_UNSPECIFIED",
+		1: "TONE_PLAYFUL",
+		2: "TONE_CORPORATE",
+		3: "TONE_TECHNICAL",
+	}
+	Tone_value = map[string]int32{
+		"TONE_UNSPECIFIED": 0,
+		"TONE_PLAYFUL":     1,
+		"TONE_CORPORATE":   2,
+		"TONE_TECHNICAL":   3,
+	}
+)
+
+func (x Tone) Enum() *Tone {
+	p := new(Tone)
+	*p = x
+	return p
+}
+
+func (x Tone) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Tone) Descriptor() protoreflect.EnumDescriptor {
+	return file_domainsearch_v1_service_proto_enumTypes[1].Descriptor()
+}
+
+func (Tone) Type() protoreflect.EnumType {
+	return &file_domainsearch_v1_service_proto_enumTypes[1]
+}
+
+func (x Tone) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Tone.Descriptor instead.
+func (Tone) EnumDescriptor() ([]byte, []int) {
+	return file_domainsearch_v1_service_proto_rawDescGZIP(), []int{1}
+}
+
+// AvailabilityStatus is the registration state of a domain.
+type AvailabilityStatus int32
+
+const (
+	// The availability has not been checked or could not be determined.
+	AvailabilityStatus_AVAILABILITY_STATUS_UNKNOWN AvailabilityStatus = 0
+	// The domain is not registered and can be purchased.
+	AvailabilityStatus_AVAILABILITY_STATUS_AVAILABLE AvailabilityStatus = 1
+	// The domain is already registered.
+	AvailabilityStatus_AVAILABILITY_STATUS_TAKEN AvailabilityStatus = 2
+)
+
+// Enum value maps for AvailabilityStatus.
+var (
+	AvailabilityStatus_name = map[int32]string{
+		0: "AVAILABILITY_STATUS_UNKNOWN",
+		1: "AVAILABILITY_STATUS_AVAILABLE",
+		2: "AVAILABILITY_STATUS_TAKEN",
+	}
+	AvailabilityStatus_value = map[string]int32{
+		"AVAILABILITY_STATUS_UNKNOWN":   0,
+		"AVAILABILITY_STATUS_AVAILABLE": 1,
+		"AVAILABILITY_STATUS_TAKEN":     2,
+	}
+)
+
+func (x AvailabilityStatus) Enum() *AvailabilityStatus {
+	p := new(AvailabilityStatus)
+	*p = x
+	return p
+}
+
+func (x AvailabilityStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AvailabilityStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_domainsearch_v1_service_proto_enumTypes[2].Descriptor()
+}
+
+func (AvailabilityStatus) Type() protoreflect.EnumType {
+	return &file_domainsearch_v1_service_proto_enumTypes[2]
+}
+
+func (x AvailabilityStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AvailabilityStatus.Descriptor instead.
+func (AvailabilityStatus) EnumDescriptor() ([]byte, []int) {
+	return file_domainsearch_v1_service_proto_rawDescGZIP(), []int{2}
+}
+
 // The request for SearchPrices method.
 type SearchPricesRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -31,11 +203,26 @@ type SearchPricesRequest struct {
 	// The query is query string for fetching products prices.
 	// For example, "domain" product if domain is example.com, query string is "example".
 	Query string `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
-	// The currency code defines the currency for requested prices. If currency_code is omitted or incorrect, product's
-	// currency will be used.
+	// The currency code defines the currency for requested prices. USD is used when omitted. When the price service
+	// cannot quote in the requested currency, the price is converted with the server's exchange rates if available,
+	// otherwise the product's currency is used.
 	CurrencyCode string `protobuf:"bytes,3,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"`
 	// The filter parameters.
-	Filter        *PriceFilter `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	Filter *PriceFilter `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	// When exclude_unavailable is set, domains that are known to be registered already are dropped from the stream
+	// instead of being returned with availability=false.
+	ExcludeUnavailable bool `protobuf:"varint,5,opt,name=exclude_unavailable,json=excludeUnavailable,proto3" json:"exclude_unavailable,omitempty"`
+	// When include_idn is set, the generator may suggest internationalized domain names written in the script of the
+	// query. They are priced in their A-label (punycode) form.
+	IncludeIdn bool `protobuf:"varint,6,opt,name=include_idn,json=includeIdn,proto3" json:"include_idn,omitempty"`
+	// When set together with includedTldNames, every suggested name is offered under each included TLD instead
+	// of the TLD chosen by the model.
+	ExpandTlds bool `protobuf:"varint,7,opt,name=expand_tlds,json=expandTlds,proto3" json:"expand_tlds,omitempty"`
+	// Describes the business the names are for, to steer the generator.
+	Brand *BrandContext `protobuf:"bytes,8,opt,name=brand,proto3" json:"brand,omitempty"`
+	// The order of the streamed prices. By default prices are streamed as soon as they are ready, in no
+	// particular order; any other value collects every price of the search first and emits them ranked.
+	Sort          SortOrder `protobuf:"varint,9,opt,name=sort,proto3,enum=domainsearch.v1.SortOrder" json:"sort,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -98,6 +285,132 @@ func (x *SearchPricesRequest) GetFilter() *PriceFilter {
 	return nil
 }
 
+func (x *SearchPricesRequest) GetExcludeUnavailable() bool {
+	if x != nil {
+		return x.ExcludeUnavailable
+	}
+	return false
+}
+
+func (x *SearchPricesRequest) GetIncludeIdn() bool {
+	if x != nil {
+		return x.IncludeIdn
+	}
+	return false
+}
+
+func (x *SearchPricesRequest) GetExpandTlds() bool {
+	if x != nil {
+		return x.ExpandTlds
+	}
+	return false
+}
+
+func (x *SearchPricesRequest) GetBrand() *BrandContext {
+	if x != nil {
+		return x.Brand
+	}
+	return nil
+}
+
+func (x *SearchPricesRequest) GetSort() SortOrder {
+	if x != nil {
+		return x.Sort
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
+// BrandContext describes the business a search generates names for.
+type BrandContext struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The kind of business, e.g. "specialty coffee roaster".
+	BusinessType string `protobuf:"bytes,1,opt,name=business_type,json=businessType,proto3" json:"business_type,omitempty"`
+	// The location or market the business targets, e.g. "Berlin" or "DACH".
+	Location string `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	// Words the names should build on.
+	BrandKeywords []string `protobuf:"bytes,3,rep,name=brand_keywords,json=brandKeywords,proto3" json:"brand_keywords,omitempty"`
+	// The voice of the names.
+	Tone Tone `protobuf:"varint,4,opt,name=tone,proto3,enum=domainsearch.v1.Tone" json:"tone,omitempty"`
+	// Words that must not appear in any suggested name. Suggestions containing them are dropped.
+	BannedWords []string `protobuf:"bytes,5,rep,name=banned_words,json=bannedWords,proto3" json:"banned_words,omitempty"`
+	// The people the brand addresses, e.g. "young parents".
+	TargetAudience string `protobuf:"bytes,6,opt,name=target_audience,json=targetAudience,proto3" json:"target_audience,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BrandContext) Reset() {
+	*x = BrandContext{}
+	mi := &file_domainsearch_v1_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BrandContext) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BrandContext) ProtoMessage() {}
+
+func (x *BrandContext) ProtoReflect() protoreflect.Message {
+	mi := &file_domainsearch_v1_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BrandContext.ProtoReflect.Descriptor instead.
+func (*BrandContext) Descriptor() ([]byte, []int) {
+	return file_domainsearch_v1_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BrandContext) GetBusinessType() string {
+	if x != nil {
+		return x.BusinessType
+	}
+	return ""
+}
+
+func (x *BrandContext) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *BrandContext) GetBrandKeywords() []string {
+	if x != nil {
+		return x.BrandKeywords
+	}
+	return nil
+}
+
+func (x *BrandContext) GetTone() Tone {
+	if x != nil {
+		return x.Tone
+	}
+	return Tone_TONE_UNSPECIFIED
+}
+
+func (x *BrandContext) GetBannedWords() []string {
+	if x != nil {
+		return x.BannedWords
+	}
+	return nil
+}
+
+func (x *BrandContext) GetTargetAudience() string {
+	if x != nil {
+		return x.TargetAudience
+	}
+	return ""
+}
+
 type PriceFilter struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Product:
@@ -110,7 +423,7 @@ type PriceFilter struct {
 
 func (x *PriceFilter) Reset() {
 	*x = PriceFilter{}
-	mi := &file_domainsearch_v1_service_proto_msgTypes[1]
+	mi := &file_domainsearch_v1_service_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -122,7 +435,7 @@ func (x *PriceFilter) String() string {
 func (*PriceFilter) ProtoMessage() {}
 
 func (x *PriceFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_domainsearch_v1_service_proto_msgTypes[1]
+	mi := &file_domainsearch_v1_service_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -135,7 +448,7 @@ func (x *PriceFilter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PriceFilter.ProtoReflect.Descriptor instead.
 func (*PriceFilter) Descriptor() ([]byte, []int) {
-	return file_domainsearch_v1_service_proto_rawDescGZIP(), []int{1}
+	return file_domainsearch_v1_service_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *PriceFilter) GetProduct() isPriceFilter_Product {
@@ -173,14 +486,21 @@ type DomainPriceFilter struct {
 	//
 	//	*DomainPriceFilter_ExcludedTldNames
 	//	*DomainPriceFilter_IncludedTldNames
-	TldFilter     isDomainPriceFilter_TldFilter `protobuf_oneof:"tldFilter"`
+	TldFilter isDomainPriceFilter_TldFilter `protobuf_oneof:"tldFilter"`
+	// The highest registration cost to return. Without a currency_code the amount is in the request currency.
+	// Prices quoted in another currency than the budget are dropped.
+	MaxCost *Money `protobuf:"bytes,4,opt,name=max_cost,json=maxCost,proto3" json:"max_cost,omitempty"`
+	// The highest renewal cost to return, with the same currency rules as max_cost.
+	MaxRenewalCost *Money `protobuf:"bytes,5,opt,name=max_renewal_cost,json=maxRenewalCost,proto3" json:"max_renewal_cost,omitempty"`
+	// When set, only prices with an active promotion are returned.
+	PromotionOnly bool `protobuf:"varint,6,opt,name=promotion_only,json=promotionOnly,proto3" json:"promotion_only,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DomainPriceFilter) Reset() {
 	*x = DomainPriceFilter{}
-	mi := &file_domainsearch_v1_service_proto_msgTypes[2]
+	mi := &file_domainsearch_v1_service_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -192,7 +512,7 @@ func (x *DomainPriceFilter) String() string {
 func (*DomainPriceFilter) ProtoMessage() {}
 
 func (x *DomainPriceFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_domainsearch_v1_service_proto_msgTypes[2]
+	mi := &file_domainsearch_v1_service_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -205,7 +525,7 @@ func (x *DomainPriceFilter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DomainPriceFilter.ProtoReflect.Descriptor instead.
 func (*DomainPriceFilter) Descriptor() ([]byte, []int) {
-	return file_domainsearch_v1_service_proto_rawDescGZIP(), []int{2}
+	return file_domainsearch_v1_service_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *DomainPriceFilter) GetQuantity() *wrapperspb.UInt32Value {
@@ -240,6 +560,27 @@ func (x *DomainPriceFilter) GetIncludedTldNames() string {
 	return ""
 }
 
+func (x *DomainPriceFilter) GetMaxCost() *Money {
+	if x != nil {
+		return x.MaxCost
+	}
+	return nil
+}
+
+func (x *DomainPriceFilter) GetMaxRenewalCost() *Money {
+	if x != nil {
+		return x.MaxRenewalCost
+	}
+	return nil
+}
+
+func (x *DomainPriceFilter) GetPromotionOnly() bool {
+	if x != nil {
+		return x.PromotionOnly
+	}
+	return false
+}
+
 type isDomainPriceFilter_TldFilter interface {
 	isDomainPriceFilter_TldFilter()
 }
@@ -269,14 +610,18 @@ type SearchPricesResponse struct {
 	//
 	//	*SearchPricesResponse_Price
 	//	*SearchPricesResponse_Error
-	Response      isSearchPricesResponse_Response `protobuf_oneof:"response"`
+	//	*SearchPricesResponse_Summary
+	Response isSearchPricesResponse_Response `protobuf_oneof:"response"`
+	// The domain a price or error concerns. A failed domain does not end the stream; the other domains keep
+	// being priced.
+	Domain        string `protobuf:"bytes,3,opt,name=domain,proto3" json:"domain,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SearchPricesResponse) Reset() {
 	*x = SearchPricesResponse{}
-	mi := &file_domainsearch_v1_service_proto_msgTypes[3]
+	mi := &file_domainsearch_v1_service_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -288,7 +633,7 @@ func (x *SearchPricesResponse) String() string {
 func (*SearchPricesResponse) ProtoMessage() {}
 
 func (x *SearchPricesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_domainsearch_v1_service_proto_msgTypes[3]
+	mi := &file_domainsearch_v1_service_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -301,7 +646,7 @@ func (x *SearchPricesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchPricesResponse.ProtoReflect.Descriptor instead.
 func (*SearchPricesResponse) Descriptor() ([]byte, []int) {
-	return file_domainsearch_v1_service_proto_rawDescGZIP(), []int{3}
+	return file_domainsearch_v1_service_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *SearchPricesResponse) GetResponse() isSearchPricesResponse_Response {
@@ -329,6 +674,22 @@ func (x *SearchPricesResponse) GetError() *status.Status {
 	return nil
 }
 
+func (x *SearchPricesResponse) GetSummary() *SearchSummary {
+	if x != nil {
+		if x, ok := x.Response.(*SearchPricesResponse_Summary); ok {
+			return x.Summary
+		}
+	}
+	return nil
+}
+
+func (x *SearchPricesResponse) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
 type isSearchPricesResponse_Response interface {
 	isSearchPricesResponse_Response()
 }
@@ -343,38 +704,143 @@ type SearchPricesResponse_Error struct {
 	Error *status.Status `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
 }
 
+type SearchPricesResponse_Summary struct {
+	// The last message of a search, counting the domains that were priced and those that failed.
+	Summary *SearchSummary `protobuf:"bytes,4,opt,name=summary,proto3,oneof"`
+}
+
 func (*SearchPricesResponse_Price) isSearchPricesResponse_Response() {}
 
 func (*SearchPricesResponse_Error) isSearchPricesResponse_Response() {}
 
+func (*SearchPricesResponse_Summary) isSearchPricesResponse_Response() {}
+
+// SearchSummary closes a price stream.
+type SearchSummary struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of suggested domains that were looked up, over every generation round.
+	Requested uint32 `protobuf:"varint,1,opt,name=requested,proto3" json:"requested,omitempty"`
+	// Number of domains for which a price was returned.
+	Priced uint32 `protobuf:"varint,2,opt,name=priced,proto3" json:"priced,omitempty"`
+	// Number of domains answered with an error instead of a price.
+	Failed uint32 `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	// Number of priced domains left out because they did not match max_cost, max_renewal_cost or promotion_only.
+	OverBudget    uint32 `protobuf:"varint,4,opt,name=over_budget,json=overBudget,proto3" json:"over_budget,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchSummary) Reset() {
+	*x = SearchSummary{}
+	mi := &file_domainsearch_v1_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchSummary) ProtoMessage() {}
+
+func (x *SearchSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_domainsearch_v1_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchSummary.ProtoReflect.Descriptor instead.
+func (*SearchSummary) Descriptor() ([]byte, []int) {
+	return file_domainsearch_v1_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SearchSummary) GetRequested() uint32 {
+	if x != nil {
+		return x.Requested
+	}
+	return 0
+}
+
+func (x *SearchSummary) GetPriced() uint32 {
+	if x != nil {
+		return x.Priced
+	}
+	return 0
+}
+
+func (x *SearchSummary) GetFailed() uint32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *SearchSummary) GetOverBudget() uint32 {
+	if x != nil {
+		return x.OverBudget
+	}
+	return 0
+}
+
 // The normalized price payload returned by the service.
 type Price struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Promotion is promotion available.
 	Promotion bool `protobuf:"varint,1,opt,name=promotion,proto3" json:"promotion,omitempty"`
 	// Cost is cost value.
+	// Deprecated: float cannot represent most decimal prices exactly; use amounts.cost.
+	//
+	// Deprecated: Marked as deprecated in domainsearch/v1/service.proto.
 	Cost float32 `protobuf:"fixed32,2,opt,name=cost,proto3" json:"cost,omitempty"`
 	// The 3-letter currency code defined in ISO 4217.
 	Currency string `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
-	// Domain is full domain name with TLD.
+	// Domain is full domain name with TLD. Internationalized names are returned in their A-label (punycode) form.
 	Domain string `protobuf:"bytes,4,opt,name=domain,proto3" json:"domain,omitempty"`
 	// Labels is array of domain labels.
 	Labels []string `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty"`
-	// Availability indicates if the domain is available for purchase.
+	// Availability indicates if the domain is available for purchase. It is only true when the availability checker
+	// confirmed the domain can be registered; see availability_status for the detailed state.
 	Availability bool `protobuf:"varint,6,opt,name=availability,proto3" json:"availability,omitempty"`
-	// Similarity score of the suggestion compared to the query.
+	// Similarity score is the relevance of the suggestion to the query, in [0, 1]. It is a weighted blend of lexical,
+	// length, pronounceability, TLD and semantic signals, configured with --score-weights; semantic_similarity carries
+	// the semantic signal on its own.
 	SimilarityScore float64 `protobuf:"fixed64,7,opt,name=similarity_score,json=similarityScore,proto3" json:"similarity_score,omitempty"`
 	// Renewal cost is approximate renewal price for the domain.
+	// Deprecated: float cannot represent most decimal prices exactly; use amounts.renewal_cost.
+	//
+	// Deprecated: Marked as deprecated in domainsearch/v1/service.proto.
 	RenewalCost float32 `protobuf:"fixed32,8,opt,name=renewal_cost,json=renewalCost,proto3" json:"renewal_cost,omitempty"`
 	// AI reasoning explaining why this domain was suggested.
-	Reasoning     string `protobuf:"bytes,9,opt,name=reasoning,proto3" json:"reasoning,omitempty"`
+	Reasoning string `protobuf:"bytes,9,opt,name=reasoning,proto3" json:"reasoning,omitempty"`
+	// Availability status as resolved by the availability checker.
+	AvailabilityStatus AvailabilityStatus `protobuf:"varint,10,opt,name=availability_status,json=availabilityStatus,proto3,enum=domainsearch.v1.AvailabilityStatus" json:"availability_status,omitempty"`
+	// Unicode domain is the U-label form of domain, e.g. bücher.de for xn--bcher-kva.de. It equals domain for
+	// ASCII-only names.
+	UnicodeDomain string `protobuf:"bytes,11,opt,name=unicode_domain,json=unicodeDomain,proto3" json:"unicode_domain,omitempty"`
+	// Conversion is set when cost and renewal_cost were converted from the currency quoted by the price service.
+	Conversion *CurrencyConversion `protobuf:"bytes,12,opt,name=conversion,proto3" json:"conversion,omitempty"`
+	// Provider names the price source that quoted this price.
+	Provider string `protobuf:"bytes,15,opt,name=provider,proto3" json:"provider,omitempty"`
+	// Rank is the 1-based position of the price in a sorted search, and 0 when the search is unsorted.
+	Rank uint32 `protobuf:"varint,17,opt,name=rank,proto3" json:"rank,omitempty"`
+	// Semantic similarity is how close the meaning of the domain's name is to the query, in [0, 1], as measured by the
+	// embeddings model alone. It is 0 when the server has no embeddings model configured or the comparison failed.
+	SemanticSimilarity float64 `protobuf:"fixed64,18,opt,name=semantic_similarity,json=semanticSimilarity,proto3" json:"semantic_similarity,omitempty"`
+	// Amounts are the exact prices of the domain, in currency.
+	Amounts       *PriceAmounts `protobuf:"bytes,19,opt,name=amounts,proto3" json:"amounts,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Price) Reset() {
 	*x = Price{}
-	mi := &file_domainsearch_v1_service_proto_msgTypes[4]
+	mi := &file_domainsearch_v1_service_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -386,7 +852,7 @@ func (x *Price) String() string {
 func (*Price) ProtoMessage() {}
 
 func (x *Price) ProtoReflect() protoreflect.Message {
-	mi := &file_domainsearch_v1_service_proto_msgTypes[4]
+	mi := &file_domainsearch_v1_service_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -399,7 +865,7 @@ func (x *Price) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Price.ProtoReflect.Descriptor instead.
 func (*Price) Descriptor() ([]byte, []int) {
-	return file_domainsearch_v1_service_proto_rawDescGZIP(), []int{4}
+	return file_domainsearch_v1_service_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Price) GetPromotion() bool {
@@ -409,6 +875,7 @@ func (x *Price) GetPromotion() bool {
 	return false
 }
 
+// Deprecated: Marked as deprecated in domainsearch/v1/service.proto.
 func (x *Price) GetCost() float32 {
 	if x != nil {
 		return x.Cost
@@ -451,6 +918,7 @@ func (x *Price) GetSimilarityScore() float64 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in domainsearch/v1/service.proto.
 func (x *Price) GetRenewalCost() float32 {
 	if x != nil {
 		return x.RenewalCost
@@ -465,18 +933,452 @@ func (x *Price) GetReasoning() string {
 	return ""
 }
 
-type DomainSuggestion struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Domain        string                 `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
-	Available     bool                   `protobuf:"varint,2,opt,name=available,proto3" json:"available,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Price) GetAvailabilityStatus() AvailabilityStatus {
+	if x != nil {
+		return x.AvailabilityStatus
+	}
+	return AvailabilityStatus_AVAILABILITY_STATUS_UNKNOWN
 }
 
-func (x *DomainSuggestion) Reset() {
-	*x = DomainSuggestion{}
-	mi := &file_domainsearch_v1_service_proto_msgTypes[5]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+func (x *Price) GetUnicodeDomain() string {
+	if x != nil {
+		return x.UnicodeDomain
+	}
+	return ""
+}
+
+func (x *Price) GetConversion() *CurrencyConversion {
+	if x != nil {
+		return x.Conversion
+	}
+	return nil
+}
+
+func (x *Price) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Price) GetRank() uint32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *Price) GetSemanticSimilarity() float64 {
+	if x != nil {
+		return x.SemanticSimilarity
+	}
+	return 0
+}
+
+func (x *Price) GetAmounts() *PriceAmounts {
+	if x != nil {
+		return x.Amounts
+	}
+	return nil
+}
+
+// PriceAmounts carries the exact prices of a domain, superseding the deprecated float fields of Price.
+type PriceAmounts struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Cost is the exact registration price.
+	Cost *Money `protobuf:"bytes,1,opt,name=cost,proto3" json:"cost,omitempty"`
+	// Renewal cost is the exact renewal price.
+	RenewalCost *Money `protobuf:"bytes,2,opt,name=renewal_cost,json=renewalCost,proto3" json:"renewal_cost,omitempty"`
+	// Transfer cost is the price of transferring the domain in, when the price source quotes it.
+	TransferCost  *Money `protobuf:"bytes,3,opt,name=transfer_cost,json=transferCost,proto3" json:"transfer_cost,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceAmounts) Reset() {
+	*x = PriceAmounts{}
+	mi := &file_domainsearch_v1_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceAmounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceAmounts) ProtoMessage() {}
+
+func (x *PriceAmounts) ProtoReflect() protoreflect.Message {
+	mi := &file_domainsearch_v1_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceAmounts.ProtoReflect.Descriptor instead.
+func (*PriceAmounts) Descriptor() ([]byte, []int) {
+	return file_domainsearch_v1_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PriceAmounts) GetCost() *Money {
+	if x != nil {
+		return x.Cost
+	}
+	return nil
+}
+
+func (x *PriceAmounts) GetRenewalCost() *Money {
+	if x != nil {
+		return x.RenewalCost
+	}
+	return nil
+}
+
+func (x *PriceAmounts) GetTransferCost() *Money {
+	if x != nil {
+		return x.TransferCost
+	}
+	return nil
+}
+
+// Money represents an exact amount of money with its currency type. It mirrors google.type.Money.
+type Money struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The 3-letter currency code defined in ISO 4217.
+	CurrencyCode string `protobuf:"bytes,1,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"`
+	// The whole units of the amount. For example if currency_code is "USD", then 1 unit is one US dollar.
+	Units int64 `protobuf:"varint,2,opt,name=units,proto3" json:"units,omitempty"`
+	// Number of nano (10^-9) units of the amount. The value must be between -999,999,999 and +999,999,999 inclusive
+	// and have the same sign as units, e.g. $-1.75 is represented as units=-1 and nanos=-750,000,000.
+	Nanos         int32 `protobuf:"varint,3,opt,name=nanos,proto3" json:"nanos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_domainsearch_v1_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_domainsearch_v1_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_domainsearch_v1_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Money) GetCurrencyCode() string {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return ""
+}
+
+func (x *Money) GetUnits() int64 {
+	if x != nil {
+		return x.Units
+	}
+	return 0
+}
+
+func (x *Money) GetNanos() int32 {
+	if x != nil {
+		return x.Nanos
+	}
+	return 0
+}
+
+// CurrencyConversion describes how a price was converted into the requested currency.
+type CurrencyConversion struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The 3-letter currency code the price service quoted in.
+	FromCurrency string `protobuf:"bytes,1,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	// Rate is the number of units of the requested currency per unit of from_currency, as a decimal string.
+	Rate string `protobuf:"bytes,2,opt,name=rate,proto3" json:"rate,omitempty"`
+	// Rate time is when the exchange rates used for the conversion were published.
+	RateTime      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=rate_time,json=rateTime,proto3" json:"rate_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CurrencyConversion) Reset() {
+	*x = CurrencyConversion{}
+	mi := &file_domainsearch_v1_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CurrencyConversion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CurrencyConversion) ProtoMessage() {}
+
+func (x *CurrencyConversion) ProtoReflect() protoreflect.Message {
+	mi := &file_domainsearch_v1_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CurrencyConversion.ProtoReflect.Descriptor instead.
+func (*CurrencyConversion) Descriptor() ([]byte, []int) {
+	return file_domainsearch_v1_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CurrencyConversion) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *CurrencyConversion) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+func (x *CurrencyConversion) GetRateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RateTime
+	}
+	return nil
+}
+
+// The request for CheckAvailability method.
+type CheckAvailabilityRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Domain is full domain name with TLD, e.g. example.com.
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// The currency code defines the currency for the returned price. USD is used when omitted.
+	CurrencyCode  string `protobuf:"bytes,2,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckAvailabilityRequest) Reset() {
+	*x = CheckAvailabilityRequest{}
+	mi := &file_domainsearch_v1_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAvailabilityRequest) ProtoMessage() {}
+
+func (x *CheckAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_domainsearch_v1_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*CheckAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_domainsearch_v1_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CheckAvailabilityRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *CheckAvailabilityRequest) GetCurrencyCode() string {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return ""
+}
+
+// The request for BulkCheckAvailability method.
+type BulkCheckAvailabilityRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Domains are full domain names with TLD. The server rejects requests above its configured limit.
+	Domains []string `protobuf:"bytes,1,rep,name=domains,proto3" json:"domains,omitempty"`
+	// The currency code defines the currency for the returned prices. USD is used when omitted.
+	CurrencyCode  string `protobuf:"bytes,2,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkCheckAvailabilityRequest) Reset() {
+	*x = BulkCheckAvailabilityRequest{}
+	mi := &file_domainsearch_v1_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkCheckAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkCheckAvailabilityRequest) ProtoMessage() {}
+
+func (x *BulkCheckAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_domainsearch_v1_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkCheckAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*BulkCheckAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_domainsearch_v1_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BulkCheckAvailabilityRequest) GetDomains() []string {
+	if x != nil {
+		return x.Domains
+	}
+	return nil
+}
+
+func (x *BulkCheckAvailabilityRequest) GetCurrencyCode() string {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return ""
+}
+
+// The availability and price of a single requested domain.
+type DomainAvailability struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Domain is the normalized domain name the result belongs to.
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// Availability status as resolved by the availability checker.
+	AvailabilityStatus AvailabilityStatus `protobuf:"varint,2,opt,name=availability_status,json=availabilityStatus,proto3,enum=domainsearch.v1.AvailabilityStatus" json:"availability_status,omitempty"`
+	// Price is the quote for the domain. It is unset when the price service did not return one.
+	Price *Price `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	// Error describes why the availability or price lookup for this domain failed.
+	Error *status.Status `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// Unicode domain is the U-label form of domain.
+	UnicodeDomain string `protobuf:"bytes,5,opt,name=unicode_domain,json=unicodeDomain,proto3" json:"unicode_domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DomainAvailability) Reset() {
+	*x = DomainAvailability{}
+	mi := &file_domainsearch_v1_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DomainAvailability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainAvailability) ProtoMessage() {}
+
+func (x *DomainAvailability) ProtoReflect() protoreflect.Message {
+	mi := &file_domainsearch_v1_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainAvailability.ProtoReflect.Descriptor instead.
+func (*DomainAvailability) Descriptor() ([]byte, []int) {
+	return file_domainsearch_v1_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DomainAvailability) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *DomainAvailability) GetAvailabilityStatus() AvailabilityStatus {
+	if x != nil {
+		return x.AvailabilityStatus
+	}
+	return AvailabilityStatus_AVAILABILITY_STATUS_UNKNOWN
+}
+
+func (x *DomainAvailability) GetPrice() *Price {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *DomainAvailability) GetError() *status.Status {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *DomainAvailability) GetUnicodeDomain() string {
+	if x != nil {
+		return x.UnicodeDomain
+	}
+	return ""
+}
+
+type DomainSuggestion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domain        string                 `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	Available     bool                   `protobuf:"varint,2,opt,name=available,proto3" json:"available,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DomainSuggestion) Reset() {
+	*x = DomainSuggestion{}
+	mi := &file_domainsearch_v1_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
@@ -487,7 +1389,7 @@ func (x *DomainSuggestion) String() string {
 func (*DomainSuggestion) ProtoMessage() {}
 
 func (x *DomainSuggestion) ProtoReflect() protoreflect.Message {
-	mi := &file_domainsearch_v1_service_proto_msgTypes[5]
+	mi := &file_domainsearch_v1_service_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -500,7 +1402,7 @@ func (x *DomainSuggestion) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DomainSuggestion.ProtoReflect.Descriptor instead.
 func (*DomainSuggestion) Descriptor() ([]byte, []int) {
-	return file_domainsearch_v1_service_proto_rawDescGZIP(), []int{5}
+	return file_domainsearch_v1_service_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *DomainSuggestion) GetDomain() string {
@@ -521,42 +1423,119 @@ var File_domainsearch_v1_service_proto protoreflect.FileDescriptor
 
 const file_domainsearch_v1_service_proto_rawDesc = "" +
 	"\n" +
-	"\x1ddomainsearch/v1/service.proto\x12\x0fdomainsearch.v1\x1a\x17google/rpc/status.proto\x1a\x1egoogle/protobuf/wrappers.proto\"\xa0\x01\n" +
+	"\x1ddomainsearch/v1/service.proto\x12\x0fdomainsearch.v1\x1a\x17google/rpc/status.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/wrappers.proto\"\xf8\x02\n" +
 	"\x13SearchPricesRequest\x12\x18\n" +
 	"\aproduct\x18\x01 \x01(\tR\aproduct\x12\x14\n" +
 	"\x05query\x18\x02 \x01(\tR\x05query\x12#\n" +
 	"\rcurrency_code\x18\x03 \x01(\tR\fcurrencyCode\x124\n" +
-	"\x06filter\x18\x04 \x01(\v2\x1c.domainsearch.v1.PriceFilterR\x06filter\"V\n" +
+	"\x06filter\x18\x04 \x01(\v2\x1c.domainsearch.v1.PriceFilterR\x06filter\x12/\n" +
+	"\x13exclude_unavailable\x18\x05 \x01(\bR\x12excludeUnavailable\x12\x1f\n" +
+	"\vinclude_idn\x18\x06 \x01(\bR\n" +
+	"includeIdn\x12\x1f\n" +
+	"\vexpand_tlds\x18\a \x01(\bR\n" +
+	"expandTlds\x123\n" +
+	"\x05brand\x18\b \x01(\v2\x1d.domainsearch.v1.BrandContextR\x05brand\x12.\n" +
+	"\x04sort\x18\t \x01(\x0e2\x1a.domainsearch.v1.SortOrderR\x04sort\"\xed\x01\n" +
+	"\fBrandContext\x12#\n" +
+	"\rbusiness_type\x18\x01 \x01(\tR\fbusinessType\x12\x1a\n" +
+	"\blocation\x18\x02 \x01(\tR\blocation\x12%\n" +
+	"\x0ebrand_keywords\x18\x03 \x03(\tR\rbrandKeywords\x12)\n" +
+	"\x04tone\x18\x04 \x01(\x0e2\x15.domainsearch.v1.ToneR\x04tone\x12!\n" +
+	"\fbanned_words\x18\x05 \x03(\tR\vbannedWords\x12'\n" +
+	"\x0ftarget_audience\x18\x06 \x01(\tR\x0etargetAudience\"V\n" +
 	"\vPriceFilter\x12<\n" +
 	"\x06domain\x18\x01 \x01(\v2\".domainsearch.v1.DomainPriceFilterH\x00R\x06domainB\t\n" +
-	"\aproduct\"\xb6\x01\n" +
+	"\aproduct\"\xd2\x02\n" +
 	"\x11DomainPriceFilter\x128\n" +
 	"\bquantity\x18\x01 \x01(\v2\x1c.google.protobuf.UInt32ValueR\bquantity\x12,\n" +
 	"\x10excludedTldNames\x18\x02 \x01(\tH\x00R\x10excludedTldNames\x12,\n" +
-	"\x10includedTldNames\x18\x03 \x01(\tH\x00R\x10includedTldNamesB\v\n" +
-	"\ttldFilter\"~\n" +
+	"\x10includedTldNames\x18\x03 \x01(\tH\x00R\x10includedTldNames\x121\n" +
+	"\bmax_cost\x18\x04 \x01(\v2\x16.domainsearch.v1.MoneyR\amaxCost\x12@\n" +
+	"\x10max_renewal_cost\x18\x05 \x01(\v2\x16.domainsearch.v1.MoneyR\x0emaxRenewalCost\x12%\n" +
+	"\x0epromotion_only\x18\x06 \x01(\bR\rpromotionOnlyB\v\n" +
+	"\ttldFilter\"\xd2\x01\n" +
 	"\x14SearchPricesResponse\x12.\n" +
 	"\x05price\x18\x01 \x01(\v2\x16.domainsearch.v1.PriceH\x00R\x05price\x12*\n" +
-	"\x05error\x18\x02 \x01(\v2\x12.google.rpc.StatusH\x00R\x05errorB\n" +
+	"\x05error\x18\x02 \x01(\v2\x12.google.rpc.StatusH\x00R\x05error\x12:\n" +
+	"\asummary\x18\x04 \x01(\v2\x1e.domainsearch.v1.SearchSummaryH\x00R\asummary\x12\x16\n" +
+	"\x06domain\x18\x03 \x01(\tR\x06domainB\n" +
 	"\n" +
-	"\bresponse\"\x95\x02\n" +
+	"\bresponse\"~\n" +
+	"\rSearchSummary\x12\x1c\n" +
+	"\trequested\x18\x01 \x01(\rR\trequested\x12\x16\n" +
+	"\x06priced\x18\x02 \x01(\rR\x06priced\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\rR\x06failed\x12\x1f\n" +
+	"\vover_budget\x18\x04 \x01(\rR\n" +
+	"overBudget\"\xc3\x05\n" +
 	"\x05Price\x12\x1c\n" +
-	"\tpromotion\x18\x01 \x01(\bR\tpromotion\x12\x12\n" +
-	"\x04cost\x18\x02 \x01(\x02R\x04cost\x12\x1a\n" +
+	"\tpromotion\x18\x01 \x01(\bR\tpromotion\x12\x16\n" +
+	"\x04cost\x18\x02 \x01(\x02B\x02\x18\x01R\x04cost\x12\x1a\n" +
 	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x16\n" +
 	"\x06domain\x18\x04 \x01(\tR\x06domain\x12\x16\n" +
 	"\x06labels\x18\x05 \x03(\tR\x06labels\x12\"\n" +
 	"\favailability\x18\x06 \x01(\bR\favailability\x12)\n" +
-	"\x10similarity_score\x18\a \x01(\x01R\x0fsimilarityScore\x12!\n" +
-	"\frenewal_cost\x18\b \x01(\x02R\vrenewalCost\x12\x1c\n" +
-	"\treasoning\x18\t \x01(\tR\treasoning\"H\n" +
+	"\x10similarity_score\x18\a \x01(\x01R\x0fsimilarityScore\x12%\n" +
+	"\frenewal_cost\x18\b \x01(\x02B\x02\x18\x01R\vrenewalCost\x12\x1c\n" +
+	"\treasoning\x18\t \x01(\tR\treasoning\x12T\n" +
+	"\x13availability_status\x18\n" +
+	" \x01(\x0e2#.domainsearch.v1.AvailabilityStatusR\x12availabilityStatus\x12%\n" +
+	"\x0eunicode_domain\x18\v \x01(\tR\runicodeDomain\x12C\n" +
+	"\n" +
+	"conversion\x18\f \x01(\v2#.domainsearch.v1.CurrencyConversionR\n" +
+	"conversion\x12\x1a\n" +
+	"\bprovider\x18\x0f \x01(\tR\bprovider\x12\x12\n" +
+	"\x04rank\x18\x11 \x01(\rR\x04rank\x12/\n" +
+	"\x13semantic_similarity\x18\x12 \x01(\x01R\x12semanticSimilarity\x127\n" +
+	"\aamounts\x18\x13 \x01(\v2\x1d.domainsearch.v1.PriceAmountsR\aamountsJ\x04\b\r\x10\x0eJ\x04\b\x0e\x10\x0fJ\x04\b\x10\x10\x11R\vcost_amountR\x13renewal_cost_amountR\x14transfer_cost_amount\"\xb2\x01\n" +
+	"\fPriceAmounts\x12*\n" +
+	"\x04cost\x18\x01 \x01(\v2\x16.domainsearch.v1.MoneyR\x04cost\x129\n" +
+	"\frenewal_cost\x18\x02 \x01(\v2\x16.domainsearch.v1.MoneyR\vrenewalCost\x12;\n" +
+	"\rtransfer_cost\x18\x03 \x01(\v2\x16.domainsearch.v1.MoneyR\ftransferCost\"X\n" +
+	"\x05Money\x12#\n" +
+	"\rcurrency_code\x18\x01 \x01(\tR\fcurrencyCode\x12\x14\n" +
+	"\x05units\x18\x02 \x01(\x03R\x05units\x12\x14\n" +
+	"\x05nanos\x18\x03 \x01(\x05R\x05nanos\"\x86\x01\n" +
+	"\x12CurrencyConversion\x12#\n" +
+	"\rfrom_currency\x18\x01 \x01(\tR\ffromCurrency\x12\x12\n" +
+	"\x04rate\x18\x02 \x01(\tR\x04rate\x127\n" +
+	"\trate_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\brateTime\"W\n" +
+	"\x18CheckAvailabilityRequest\x12\x16\n" +
+	"\x06domain\x18\x01 \x01(\tR\x06domain\x12#\n" +
+	"\rcurrency_code\x18\x02 \x01(\tR\fcurrencyCode\"]\n" +
+	"\x1cBulkCheckAvailabilityRequest\x12\x18\n" +
+	"\adomains\x18\x01 \x03(\tR\adomains\x12#\n" +
+	"\rcurrency_code\x18\x02 \x01(\tR\fcurrencyCode\"\x81\x02\n" +
+	"\x12DomainAvailability\x12\x16\n" +
+	"\x06domain\x18\x01 \x01(\tR\x06domain\x12T\n" +
+	"\x13availability_status\x18\x02 \x01(\x0e2#.domainsearch.v1.AvailabilityStatusR\x12availabilityStatus\x12,\n" +
+	"\x05price\x18\x03 \x01(\v2\x16.domainsearch.v1.PriceR\x05price\x12(\n" +
+	"\x05error\x18\x04 \x01(\v2\x12.google.rpc.StatusR\x05error\x12%\n" +
+	"\x0eunicode_domain\x18\x05 \x01(\tR\runicodeDomain\"H\n" +
 	"\x10DomainSuggestion\x12\x16\n" +
 	"\x06domain\x18\x01 \x01(\tR\x06domain\x12\x1c\n" +
-	"\tavailable\x18\x02 \x01(\bR\tavailable2\xd4\x01\n" +
+	"\tavailable\x18\x02 \x01(\bR\tavailable*\xae\x01\n" +
+	"\tSortOrder\x12\x1a\n" +
+	"\x16SORT_ORDER_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14SORT_ORDER_RELEVANCE\x10\x01\x12\x18\n" +
+	"\x14SORT_ORDER_PRICE_ASC\x10\x02\x12\x1a\n" +
+	"\x16SORT_ORDER_RENEWAL_ASC\x10\x03\x12\x15\n" +
+	"\x11SORT_ORDER_LENGTH\x10\x04\x12\x1e\n" +
+	"\x1aSORT_ORDER_THREE_YEAR_COST\x10\x05*V\n" +
+	"\x04Tone\x12\x14\n" +
+	"\x10TONE_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fTONE_PLAYFUL\x10\x01\x12\x12\n" +
+	"\x0eTONE_CORPORATE\x10\x02\x12\x12\n" +
+	"\x0eTONE_TECHNICAL\x10\x03*w\n" +
+	"\x12AvailabilityStatus\x12\x1f\n" +
+	"\x1bAVAILABILITY_STATUS_UNKNOWN\x10\x00\x12!\n" +
+	"\x1dAVAILABILITY_STATUS_AVAILABLE\x10\x01\x12\x1d\n" +
+	"\x19AVAILABILITY_STATUS_TAKEN\x10\x022\xa8\x03\n" +
 	"\x13DomainSearchService\x12[\n" +
 	"\n" +
 	"CheckPrice\x12$.domainsearch.v1.SearchPricesRequest\x1a%.domainsearch.v1.SearchPricesResponse0\x01\x12`\n" +
-	"\x0fCheckPriceAgent\x12$.domainsearch.v1.SearchPricesRequest\x1a%.domainsearch.v1.SearchPricesResponse0\x01BRZPgithub.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1;domainsearchv1b\x06proto3"
+	"\x0fCheckPriceAgent\x12$.domainsearch.v1.SearchPricesRequest\x1a%.domainsearch.v1.SearchPricesResponse0\x01\x12c\n" +
+	"\x11CheckAvailability\x12).domainsearch.v1.CheckAvailabilityRequest\x1a#.domainsearch.v1.DomainAvailability\x12m\n" +
+	"\x15BulkCheckAvailability\x12-.domainsearch.v1.BulkCheckAvailabilityRequest\x1a#.domainsearch.v1.DomainAvailability0\x01BRZPgithub.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1;domainsearchv1b\x06proto3"
 
 var (
 	file_domainsearch_v1_service_proto_rawDescOnce sync.Once
@@ -570,32 +1549,65 @@ func file_domainsearch_v1_service_proto_rawDescGZIP() []byte {
 	return file_domainsearch_v1_service_proto_rawDescData
 }
 
-var file_domainsearch_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_domainsearch_v1_service_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_domainsearch_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_domainsearch_v1_service_proto_goTypes = []any{
-	(*SearchPricesRequest)(nil),    // 0: domainsearch.v1.SearchPricesRequest
-	(*PriceFilter)(nil),            // 1: domainsearch.v1.PriceFilter
-	(*DomainPriceFilter)(nil),      // 2: domainsearch.v1.DomainPriceFilter
-	(*SearchPricesResponse)(nil),   // 3: domainsearch.v1.SearchPricesResponse
-	(*Price)(nil),                  // 4: domainsearch.v1.Price
-	(*DomainSuggestion)(nil),       // 5: domainsearch.v1.DomainSuggestion
-	(*wrapperspb.UInt32Value)(nil), // 6: google.protobuf.UInt32Value
-	(*status.Status)(nil),          // 7: google.rpc.Status
+	(SortOrder)(0),                       // 0: domainsearch.v1.SortOrder
+	(Tone)(0),                            // 1: domainsearch.v1.Tone
+	(AvailabilityStatus)(0),              // 2: domainsearch.v1.AvailabilityStatus
+	(*SearchPricesRequest)(nil),          // 3: domainsearch.v1.SearchPricesRequest
+	(*BrandContext)(nil),                 // 4: domainsearch.v1.BrandContext
+	(*PriceFilter)(nil),                  // 5: domainsearch.v1.PriceFilter
+	(*DomainPriceFilter)(nil),            // 6: domainsearch.v1.DomainPriceFilter
+	(*SearchPricesResponse)(nil),         // 7: domainsearch.v1.SearchPricesResponse
+	(*SearchSummary)(nil),                // 8: domainsearch.v1.SearchSummary
+	(*Price)(nil),                        // 9: domainsearch.v1.Price
+	(*PriceAmounts)(nil),                 // 10: domainsearch.v1.PriceAmounts
+	(*Money)(nil),                        // 11: domainsearch.v1.Money
+	(*CurrencyConversion)(nil),           // 12: domainsearch.v1.CurrencyConversion
+	(*CheckAvailabilityRequest)(nil),     // 13: domainsearch.v1.CheckAvailabilityRequest
+	(*BulkCheckAvailabilityRequest)(nil), // 14: domainsearch.v1.BulkCheckAvailabilityRequest
+	(*DomainAvailability)(nil),           // 15: domainsearch.v1.DomainAvailability
+	(*DomainSuggestion)(nil),             // 16: domainsearch.v1.DomainSuggestion
+	(*wrapperspb.UInt32Value)(nil),       // 17: google.protobuf.UInt32Value
+	(*status.Status)(nil),                // 18: google.rpc.Status
+	(*timestamppb.Timestamp)(nil),        // 19: google.protobuf.Timestamp
 }
 var file_domainsearch_v1_service_proto_depIdxs = []int32{
-	1, // 0: domainsearch.v1.SearchPricesRequest.filter:type_name -> domainsearch.v1.PriceFilter
-	2, // 1: domainsearch.v1.PriceFilter.domain:type_name -> domainsearch.v1.DomainPriceFilter
-	6, // 2: domainsearch.v1.DomainPriceFilter.quantity:type_name -> google.protobuf.UInt32Value
-	4, // 3: domainsearch.v1.SearchPricesResponse.price:type_name -> domainsearch.v1.Price
-	7, // 4: domainsearch.v1.SearchPricesResponse.error:type_name -> google.rpc.Status
-	0, // 5: domainsearch.v1.DomainSearchService.CheckPrice:input_type -> domainsearch.v1.SearchPricesRequest
-	0, // 6: domainsearch.v1.DomainSearchService.CheckPriceAgent:input_type -> domainsearch.v1.SearchPricesRequest
-	3, // 7: domainsearch.v1.DomainSearchService.CheckPrice:output_type -> domainsearch.v1.SearchPricesResponse
-	3, // 8: domainsearch.v1.DomainSearchService.CheckPriceAgent:output_type -> domainsearch.v1.SearchPricesResponse
-	7, // [7:9] is the sub-list for method output_type
-	5, // [5:7] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	5,  // 0: domainsearch.v1.SearchPricesRequest.filter:type_name -> domainsearch.v1.PriceFilter
+	4,  // 1: domainsearch.v1.SearchPricesRequest.brand:type_name -> domainsearch.v1.BrandContext
+	0,  // 2: domainsearch.v1.SearchPricesRequest.sort:type_name -> domainsearch.v1.SortOrder
+	1,  // 3: domainsearch.v1.BrandContext.tone:type_name -> domainsearch.v1.Tone
+	6,  // 4: domainsearch.v1.PriceFilter.domain:type_name -> domainsearch.v1.DomainPriceFilter
+	17, // 5: domainsearch.v1.DomainPriceFilter.quantity:type_name -> google.protobuf.UInt32Value
+	11, // 6: domainsearch.v1.DomainPriceFilter.max_cost:type_name -> domainsearch.v1.Money
+	11, // 7: domainsearch.v1.DomainPriceFilter.max_renewal_cost:type_name -> domainsearch.v1.Money
+	9,  // 8: domainsearch.v1.SearchPricesResponse.price:type_name -> domainsearch.v1.Price
+	18, // 9: domainsearch.v1.SearchPricesResponse.error:type_name -> google.rpc.Status
+	8,  // 10: domainsearch.v1.SearchPricesResponse.summary:type_name -> domainsearch.v1.SearchSummary
+	2,  // 11: domainsearch.v1.Price.availability_status:type_name -> domainsearch.v1.AvailabilityStatus
+	12, // 12: domainsearch.v1.Price.conversion:type_name -> domainsearch.v1.CurrencyConversion
+	10, // 13: domainsearch.v1.Price.amounts:type_name -> domainsearch.v1.PriceAmounts
+	11, // 14: domainsearch.v1.PriceAmounts.cost:type_name -> domainsearch.v1.Money
+	11, // 15: domainsearch.v1.PriceAmounts.renewal_cost:type_name -> domainsearch.v1.Money
+	11, // 16: domainsearch.v1.PriceAmounts.transfer_cost:type_name -> domainsearch.v1.Money
+	19, // 17: domainsearch.v1.CurrencyConversion.rate_time:type_name -> google.protobuf.Timestamp
+	2,  // 18: domainsearch.v1.DomainAvailability.availability_status:type_name -> domainsearch.v1.AvailabilityStatus
+	9,  // 19: domainsearch.v1.DomainAvailability.price:type_name -> domainsearch.v1.Price
+	18, // 20: domainsearch.v1.DomainAvailability.error:type_name -> google.rpc.Status
+	3,  // 21: domainsearch.v1.DomainSearchService.CheckPrice:input_type -> domainsearch.v1.SearchPricesRequest
+	3,  // 22: domainsearch.v1.DomainSearchService.CheckPriceAgent:input_type -> domainsearch.v1.SearchPricesRequest
+	13, // 23: domainsearch.v1.DomainSearchService.CheckAvailability:input_type -> domainsearch.v1.CheckAvailabilityRequest
+	14, // 24: domainsearch.v1.DomainSearchService.BulkCheckAvailability:input_type -> domainsearch.v1.BulkCheckAvailabilityRequest
+	7,  // 25: domainsearch.v1.DomainSearchService.CheckPrice:output_type -> domainsearch.v1.SearchPricesResponse
+	7,  // 26: domainsearch.v1.DomainSearchService.CheckPriceAgent:output_type -> domainsearch.v1.SearchPricesResponse
+	15, // 27: domainsearch.v1.DomainSearchService.CheckAvailability:output_type -> domainsearch.v1.DomainAvailability
+	15, // 28: domainsearch.v1.DomainSearchService.BulkCheckAvailability:output_type -> domainsearch.v1.DomainAvailability
+	25, // [25:29] is the sub-list for method output_type
+	21, // [21:25] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_domainsearch_v1_service_proto_init() }
@@ -603,29 +1615,31 @@ func file_domainsearch_v1_service_proto_init() {
 	if File_domainsearch_v1_service_proto != nil {
 		return
 	}
-	file_domainsearch_v1_service_proto_msgTypes[1].OneofWrappers = []any{
+	file_domainsearch_v1_service_proto_msgTypes[2].OneofWrappers = []any{
 		(*PriceFilter_Domain)(nil),
 	}
-	file_domainsearch_v1_service_proto_msgTypes[2].OneofWrappers = []any{
+	file_domainsearch_v1_service_proto_msgTypes[3].OneofWrappers = []any{
 		(*DomainPriceFilter_ExcludedTldNames)(nil),
 		(*DomainPriceFilter_IncludedTldNames)(nil),
 	}
-	file_domainsearch_v1_service_proto_msgTypes[3].OneofWrappers = []any{
+	file_domainsearch_v1_service_proto_msgTypes[4].OneofWrappers = []any{
 		(*SearchPricesResponse_Price)(nil),
 		(*SearchPricesResponse_Error)(nil),
+		(*SearchPricesResponse_Summary)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_domainsearch_v1_service_proto_rawDesc), len(file_domainsearch_v1_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      3,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_domainsearch_v1_service_proto_goTypes,
 		DependencyIndexes: file_domainsearch_v1_service_proto_depIdxs,
+		EnumInfos:         file_domainsearch_v1_service_proto_enumTypes,
 		MessageInfos:      file_domainsearch_v1_service_proto_msgTypes,
 	}.Build()
 	File_domainsearch_v1_service_proto = out.File
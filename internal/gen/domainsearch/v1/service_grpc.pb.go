@@ -19,8 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	DomainSearchService_CheckPrice_FullMethodName      = "/domainsearch.v1.DomainSearchService/CheckPrice"
-	DomainSearchService_CheckPriceAgent_FullMethodName = "/domainsearch.v1.DomainSearchService/CheckPriceAgent"
+	DomainSearchService_CheckPrice_FullMethodName            = "/domainsearch.v1.DomainSearchService/CheckPrice"
+	DomainSearchService_CheckPriceAgent_FullMethodName       = "/domainsearch.v1.DomainSearchService/CheckPriceAgent"
+	DomainSearchService_CheckAvailability_FullMethodName     = "/domainsearch.v1.DomainSearchService/CheckAvailability"
+	DomainSearchService_BulkCheckAvailability_FullMethodName = "/domainsearch.v1.DomainSearchService/BulkCheckAvailability"
 )
 
 // DomainSearchServiceClient is the client API for DomainSearchService service.
@@ -29,6 +31,8 @@ const (
 type DomainSearchServiceClient interface {
 	CheckPrice(ctx context.Context, in *SearchPricesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SearchPricesResponse], error)
 	CheckPriceAgent(ctx context.Context, in *SearchPricesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SearchPricesResponse], error)
+	CheckAvailability(ctx context.Context, in *CheckAvailabilityRequest, opts ...grpc.CallOption) (*DomainAvailability, error)
+	BulkCheckAvailability(ctx context.Context, in *BulkCheckAvailabilityRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DomainAvailability], error)
 }
 
 type domainSearchServiceClient struct {
@@ -77,12 +81,43 @@ func (c *domainSearchServiceClient) CheckPriceAgent(ctx context.Context, in *Sea
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type DomainSearchService_CheckPriceAgentClient = grpc.ServerStreamingClient[SearchPricesResponse]
 
+func (c *domainSearchServiceClient) CheckAvailability(ctx context.Context, in *CheckAvailabilityRequest, opts ...grpc.CallOption) (*DomainAvailability, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DomainAvailability)
+	err := c.cc.Invoke(ctx, DomainSearchService_CheckAvailability_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *domainSearchServiceClient) BulkCheckAvailability(ctx context.Context, in *BulkCheckAvailabilityRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DomainAvailability], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DomainSearchService_ServiceDesc.Streams[2], DomainSearchService_BulkCheckAvailability_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BulkCheckAvailabilityRequest, DomainAvailability]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DomainSearchService_BulkCheckAvailabilityClient = grpc.ServerStreamingClient[DomainAvailability]
+
 // DomainSearchServiceServer is the server API for DomainSearchService service.
 // All implementations must embed UnimplementedDomainSearchServiceServer
 // for forward compatibility.
 type DomainSearchServiceServer interface {
 	CheckPrice(*SearchPricesRequest, grpc.ServerStreamingServer[SearchPricesResponse]) error
 	CheckPriceAgent(*SearchPricesRequest, grpc.ServerStreamingServer[SearchPricesResponse]) error
+	CheckAvailability(context.Context, *CheckAvailabilityRequest) (*DomainAvailability, error)
+	BulkCheckAvailability(*BulkCheckAvailabilityRequest, grpc.ServerStreamingServer[DomainAvailability]) error
 	mustEmbedUnimplementedDomainSearchServiceServer()
 }
 
@@ -99,6 +134,12 @@ func (UnimplementedDomainSearchServiceServer) CheckPrice(*SearchPricesRequest, g
 func (UnimplementedDomainSearchServiceServer) CheckPriceAgent(*SearchPricesRequest, grpc.ServerStreamingServer[SearchPricesResponse]) error {
 	return status.Error(codes.Unimplemented, "method CheckPriceAgent not implemented")
 }
+func (UnimplementedDomainSearchServiceServer) CheckAvailability(context.Context, *CheckAvailabilityRequest) (*DomainAvailability, error) {
+	return nil, status.Error(codes.Unimplemented, "method CheckAvailability not implemented")
+}
+func (UnimplementedDomainSearchServiceServer) BulkCheckAvailability(*BulkCheckAvailabilityRequest, grpc.ServerStreamingServer[DomainAvailability]) error {
+	return status.Error(codes.Unimplemented, "method BulkCheckAvailability not implemented")
+}
 func (UnimplementedDomainSearchServiceServer) mustEmbedUnimplementedDomainSearchServiceServer() {}
 func (UnimplementedDomainSearchServiceServer) testEmbeddedByValue()                             {}
 
@@ -142,13 +183,47 @@ func _DomainSearchService_CheckPriceAgent_Handler(srv interface{}, stream grpc.S
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type DomainSearchService_CheckPriceAgentServer = grpc.ServerStreamingServer[SearchPricesResponse]
 
+func _DomainSearchService_CheckAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckAvailabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DomainSearchServiceServer).CheckAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DomainSearchService_CheckAvailability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DomainSearchServiceServer).CheckAvailability(ctx, req.(*CheckAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DomainSearchService_BulkCheckAvailability_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BulkCheckAvailabilityRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DomainSearchServiceServer).BulkCheckAvailability(m, &grpc.GenericServerStream[BulkCheckAvailabilityRequest, DomainAvailability]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DomainSearchService_BulkCheckAvailabilityServer = grpc.ServerStreamingServer[DomainAvailability]
+
 // DomainSearchService_ServiceDesc is the grpc.ServiceDesc for DomainSearchService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var DomainSearchService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "domainsearch.v1.DomainSearchService",
 	HandlerType: (*DomainSearchServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CheckAvailability",
+			Handler:    _DomainSearchService_CheckAvailability_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "CheckPrice",
@@ -160,6 +235,11 @@ var DomainSearchService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _DomainSearchService_CheckPriceAgent_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "BulkCheckAvailability",
+			Handler:       _DomainSearchService_BulkCheckAvailability_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "domainsearch/v1/service.proto",
 }
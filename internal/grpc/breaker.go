@@ -0,0 +1,143 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultBreakerCooldown = 30 * time.Second
+
+// Breaker is a circuit breaker shared by the calls of a connection. After threshold consecutive calls fail
+// because the server is unhealthy, calls fail fast with Unavailable for the cooldown; a single probe call
+// is then let through and closes the circuit again when it succeeds.
+type Breaker struct {
+	target    string
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+// NewBreaker returns a Breaker for the named target.
+func NewBreaker(target string, threshold int, cooldown time.Duration) *Breaker {
+	if cooldown <= 0 {
+		cooldown = defaultBreakerCooldown
+	}
+	return &Breaker{target: target, threshold: threshold, cooldown: cooldown}
+}
+
+// Allow reports whether a call may proceed, returning an Unavailable status error while the circuit is open.
+// Every allowed call must be followed by exactly one Record.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < b.threshold {
+		return nil
+	}
+	if wait := time.Until(b.openUntil); wait > 0 {
+		return status.Errorf(codes.Unavailable, "%s is unavailable: circuit open after %d consecutive failures, retrying in %s",
+			b.target, b.failures, wait.Round(time.Second))
+	}
+	if b.probing {
+		return status.Errorf(codes.Unavailable, "%s is unavailable: circuit open after %d consecutive failures, probing", b.target, b.failures)
+	}
+	b.probing = true
+	return nil
+}
+
+// Record reports the outcome of an allowed call. Cancellation by the caller says nothing about the server's
+// health and only releases a pending probe.
+func (b *Breaker) Record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	switch {
+	case err == nil:
+		b.failures = 0
+	case unhealthy(err):
+		b.failures++
+		if b.failures >= b.threshold {
+			b.openUntil = time.Now().Add(b.cooldown)
+		}
+	}
+}
+
+// unhealthy reports whether a call failure points at the server or the network rather than the request.
+// Context errors are mapped to their status code first, so a cancelled call is never counted.
+func unhealthy(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok {
+		st = status.FromContextError(err)
+	}
+	switch st.Code() {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal, codes.Unknown:
+		return true
+	default:
+		return false
+	}
+}
+
+// UnaryClientInterceptor guards unary calls with the breaker.
+func (b *Breaker) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if err := b.Allow(); err != nil {
+			return err
+		}
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		b.Record(err)
+		return err
+	}
+}
+
+// StreamClientInterceptor guards streaming calls with the breaker. A stream is recorded once, when it ends.
+func (b *Breaker) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if err := b.Allow(); err != nil {
+			return nil, err
+		}
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			b.Record(err)
+			return nil, err
+		}
+		guarded := &breakerStream{ClientStream: stream, breaker: b}
+		// Streams the caller abandons before reading them to the end still release a pending probe.
+		guarded.stop = context.AfterFunc(ctx, func() { guarded.record(context.Canceled) })
+		return guarded, nil
+	}
+}
+
+type breakerStream struct {
+	grpc.ClientStream
+	breaker *Breaker
+	stop    func() bool
+	once    sync.Once
+}
+
+func (s *breakerStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == nil {
+		return nil
+	}
+	s.stop()
+	if errors.Is(err, io.EOF) {
+		s.record(nil)
+	} else {
+		s.record(err)
+	}
+	return err
+}
+
+func (s *breakerStream) record(err error) {
+	s.once.Do(func() { s.breaker.Record(err) })
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func failCalls(t *testing.T, b *Breaker, n int, err error) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if allowErr := b.Allow(); allowErr != nil {
+			t.Fatalf("call %d rejected: %v", i, allowErr)
+		}
+		b.Record(err)
+	}
+}
+
+func TestBreakerIgnoresCancellation(t *testing.T) {
+	b := NewBreaker("prices", 3, time.Minute)
+	failCalls(t, b, 5, context.Canceled)
+	failCalls(t, b, 5, fmt.Errorf("recv: %w", context.Canceled))
+	failCalls(t, b, 5, status.Error(codes.Canceled, "cancelled"))
+	if err := b.Allow(); err != nil {
+		t.Fatalf("cancelled calls opened the circuit: %v", err)
+	}
+}
+
+func TestBreakerOpensAtThreshold(t *testing.T) {
+	b := NewBreaker("prices", 3, time.Minute)
+	failCalls(t, b, 2, status.Error(codes.Unavailable, "down"))
+	failCalls(t, b, 1, nil)
+	failCalls(t, b, 2, status.Error(codes.Unavailable, "down"))
+	if err := b.Allow(); err != nil {
+		t.Fatalf("a success should reset the failure count: %v", err)
+	}
+	b.Record(status.Error(codes.DeadlineExceeded, "slow"))
+
+	err := b.Allow()
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("Allow() = %v, want Unavailable", err)
+	}
+}
+
+func TestBreakerIgnoresRequestErrors(t *testing.T) {
+	b := NewBreaker("prices", 2, time.Minute)
+	failCalls(t, b, 4, status.Error(codes.InvalidArgument, "bad request"))
+	failCalls(t, b, 4, status.Error(codes.NotFound, "unknown tld"))
+	if err := b.Allow(); err != nil {
+		t.Fatalf("request errors opened the circuit: %v", err)
+	}
+}
+
+func TestBreakerProbesAfterCooldown(t *testing.T) {
+	b := NewBreaker("prices", 1, 20*time.Millisecond)
+	failCalls(t, b, 1, status.Error(codes.Unavailable, "down"))
+	if err := b.Allow(); err == nil {
+		t.Fatal("circuit should be open during the cooldown")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("probe rejected after the cooldown: %v", err)
+	}
+	if err := b.Allow(); err == nil {
+		t.Fatal("only one probe may run at a time")
+	}
+	b.Record(status.Error(codes.Unavailable, "still down"))
+	if err := b.Allow(); err == nil {
+		t.Fatal("a failed probe should reopen the circuit")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("probe rejected after the second cooldown: %v", err)
+	}
+	b.Record(context.Canceled)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("a cancelled probe should release the probe slot: %v", err)
+	}
+	b.Record(nil)
+	for i := 0; i < 3; i++ {
+		if err := b.Allow(); err != nil {
+			t.Fatalf("circuit should be closed after a successful probe: %v", err)
+		}
+		b.Record(nil)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"strings"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -15,6 +16,18 @@ type Config struct {
 	Insecure           bool
 	WaitForReady       bool
 	InsecureSkipVerify bool
+
+	// CallTimeout bounds every call, streams included; zero leaves calls without a deadline.
+	CallTimeout time.Duration
+	// MaxAttempts is how often a call failing with UNAVAILABLE or RESOURCE_EXHAUSTED is tried, the first
+	// attempt included; gRPC caps it at 5. Retries back off exponentially from InitialBackoff to MaxBackoff.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// BreakerThreshold is the number of consecutive failed calls that opens the circuit breaker for
+	// BreakerCooldown; zero disables the breaker.
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
 }
 
 func New(cfg *Config) (*grpc.ClientConn, error) {
@@ -45,10 +58,21 @@ func DialOptions(cfg *Config, opts ...grpc.DialOption) []grpc.DialOption {
 		))
 	}
 
-	return append(opts,
+	opts = append(opts,
 		tlsOption,
 		grpc.WithDefaultCallOptions(
 			grpc.WaitForReady(cfg.WaitForReady),
 		),
 	)
+	if sc := serviceConfig(cfg); sc != "" {
+		opts = append(opts, grpc.WithDefaultServiceConfig(sc))
+	}
+	if cfg.BreakerThreshold > 0 {
+		breaker := NewBreaker(cfg.Target, cfg.BreakerThreshold, cfg.BreakerCooldown)
+		opts = append(opts,
+			grpc.WithChainUnaryInterceptor(breaker.UnaryClientInterceptor()),
+			grpc.WithChainStreamInterceptor(breaker.StreamClientInterceptor()),
+		)
+	}
+	return opts
 }
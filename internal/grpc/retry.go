@@ -0,0 +1,70 @@
+package client
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// retryableCodes are the status codes retried by the retry policy: the call was rejected before the server
+// did any work, so trying again is safe.
+var retryableCodes = []string{"UNAVAILABLE", "RESOURCE_EXHAUSTED"}
+
+const (
+	defaultInitialBackoff = 100 * time.Millisecond
+	defaultMaxBackoff     = 2 * time.Second
+)
+
+type serviceConfigJSON struct {
+	MethodConfig []methodConfigJSON `json:"methodConfig"`
+}
+
+type methodConfigJSON struct {
+	Name        []struct{}       `json:"name"`
+	Timeout     string           `json:"timeout,omitempty"`
+	RetryPolicy *retryPolicyJSON `json:"retryPolicy,omitempty"`
+}
+
+type retryPolicyJSON struct {
+	MaxAttempts          int      `json:"maxAttempts"`
+	InitialBackoff       string   `json:"initialBackoff"`
+	MaxBackoff           string   `json:"maxBackoff"`
+	BackoffMultiplier    float64  `json:"backoffMultiplier"`
+	RetryableStatusCodes []string `json:"retryableStatusCodes"`
+}
+
+// serviceConfig renders the call timeout and retry policy of cfg as a gRPC service config applying to every
+// method. gRPC randomizes each backoff between zero and the current delay, so retries from many clients do
+// not line up. It returns "" when neither is configured.
+func serviceConfig(cfg *Config) string {
+	method := methodConfigJSON{Name: []struct{}{{}}}
+	if cfg.CallTimeout > 0 {
+		method.Timeout = jsonDuration(cfg.CallTimeout)
+	}
+	if cfg.MaxAttempts > 1 {
+		initial, maxBackoff := cfg.InitialBackoff, cfg.MaxBackoff
+		if initial <= 0 {
+			initial = defaultInitialBackoff
+		}
+		if maxBackoff < initial {
+			maxBackoff = max(initial, defaultMaxBackoff)
+		}
+		method.RetryPolicy = &retryPolicyJSON{
+			MaxAttempts:          cfg.MaxAttempts,
+			InitialBackoff:       jsonDuration(initial),
+			MaxBackoff:           jsonDuration(maxBackoff),
+			BackoffMultiplier:    2,
+			RetryableStatusCodes: retryableCodes,
+		}
+	}
+	if method.Timeout == "" && method.RetryPolicy == nil {
+		return ""
+	}
+	data, _ := json.Marshal(serviceConfigJSON{MethodConfig: []methodConfigJSON{method}})
+	return string(data)
+}
+
+// jsonDuration formats d the way the service config expects, e.g. "0.25s".
+func jsonDuration(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
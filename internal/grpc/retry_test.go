@@ -0,0 +1,85 @@
+package client
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestServiceConfig(t *testing.T) {
+	retryPolicy := func(attempts int, initial, maxBackoff string) *retryPolicyJSON {
+		return &retryPolicyJSON{
+			MaxAttempts:          attempts,
+			InitialBackoff:       initial,
+			MaxBackoff:           maxBackoff,
+			BackoffMultiplier:    2,
+			RetryableStatusCodes: []string{"UNAVAILABLE", "RESOURCE_EXHAUSTED"},
+		}
+	}
+	tests := []struct {
+		name string
+		cfg  Config
+		want *methodConfigJSON
+	}{
+		{name: "neither", cfg: Config{}},
+		{name: "single attempt", cfg: Config{MaxAttempts: 1, InitialBackoff: time.Second}},
+		{
+			name: "timeout only",
+			cfg:  Config{CallTimeout: 250 * time.Millisecond},
+			want: &methodConfigJSON{Timeout: "0.25s"},
+		},
+		{
+			name: "retry only",
+			cfg:  Config{MaxAttempts: 3, InitialBackoff: 200 * time.Millisecond, MaxBackoff: 5 * time.Second},
+			want: &methodConfigJSON{RetryPolicy: retryPolicy(3, "0.2s", "5s")},
+		},
+		{
+			name: "both",
+			cfg:  Config{CallTimeout: 3 * time.Second, MaxAttempts: 4, InitialBackoff: time.Second, MaxBackoff: 10 * time.Second},
+			want: &methodConfigJSON{Timeout: "3s", RetryPolicy: retryPolicy(4, "1s", "10s")},
+		},
+		{
+			name: "default backoff",
+			cfg:  Config{MaxAttempts: 2},
+			want: &methodConfigJSON{RetryPolicy: retryPolicy(2, "0.1s", "2s")},
+		},
+		{
+			name: "max backoff below initial falls back to the default",
+			cfg:  Config{MaxAttempts: 3, InitialBackoff: 500 * time.Millisecond, MaxBackoff: 200 * time.Millisecond},
+			want: &methodConfigJSON{RetryPolicy: retryPolicy(3, "0.5s", "2s")},
+		},
+		{
+			name: "max backoff below an initial above the default is raised to it",
+			cfg:  Config{MaxAttempts: 3, InitialBackoff: 3 * time.Second, MaxBackoff: time.Second},
+			want: &methodConfigJSON{RetryPolicy: retryPolicy(3, "3s", "3s")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := serviceConfig(&tt.cfg)
+			if tt.want == nil {
+				if raw != "" {
+					t.Fatalf("serviceConfig = %s, want none", raw)
+				}
+				return
+			}
+
+			var got serviceConfigJSON
+			if err := json.Unmarshal([]byte(raw), &got); err != nil {
+				t.Fatalf("decoding %s: %v", raw, err)
+			}
+			if len(got.MethodConfig) != 1 {
+				t.Fatalf("serviceConfig = %s, want one method config", raw)
+			}
+			method := got.MethodConfig[0]
+			if len(method.Name) != 1 {
+				t.Errorf("method config should match every method with a single empty name: %s", raw)
+			}
+			method.Name = nil
+			if !reflect.DeepEqual(method, *tt.want) {
+				t.Errorf("serviceConfig = %s, want %+v", raw, *tt.want)
+			}
+		})
+	}
+}
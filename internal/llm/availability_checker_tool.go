@@ -2,38 +2,28 @@ package llm
 
 import (
 	"context"
-	"fmt"
-	"net/http"
 
+	"github.com/olaysco/domain-search-llm/internal/provider"
 	"github.com/tmc/langchaingo/llms"
 )
 
 type AvailablityCheckerTool struct {
-	client *http.Client
+	checker provider.AvailabilityChecker
 }
 
-func NewAvailabilityCheckerTool() *AvailablityCheckerTool {
+func NewAvailabilityCheckerTool(checker provider.AvailabilityChecker) *AvailablityCheckerTool {
 	return &AvailablityCheckerTool{
-		client: http.DefaultClient,
+		checker: checker,
 	}
 }
 
+// Call reports "available", "taken" or "unknown" so the model never mistakes a failed lookup for a free domain.
 func (pct *AvailablityCheckerTool) Call(ctx context.Context, domain string) (string, error) {
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
-
-	url := fmt.Sprintf("https://rdap.verisign.com/com/v1/domain/%s", domain)
-	resp, err := pct.client.Get(url)
-
+	availability, err := pct.checker.CheckAvailability(ctx, parseToolArguments(domain).Name)
 	if err != nil {
-		return "true", nil
+		return provider.AvailabilityUnknown.String(), nil
 	}
-
-	if resp.StatusCode == 200 {
-		return "false", nil
-	}
-
-	return "true", nil
+	return availability.String(), nil
 }
 
 func (pct *AvailablityCheckerTool) Name() string {
@@ -0,0 +1,201 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/anthropic"
+	"github.com/tmc/langchaingo/llms/ollama"
+	"github.com/tmc/langchaingo/llms/openai"
+)
+
+// Supported values for Config.AIProvider.
+const (
+	ProviderOpenAI    = "openai"
+	ProviderMistral   = "mistral"
+	ProviderAnthropic = "anthropic"
+	ProviderOllama    = "ollama"
+)
+
+// defaultMistralEndpoint is the Mistral API, whose OpenAI-compatible routes are reached under /v1.
+const defaultMistralEndpoint = "https://api.mistral.ai"
+
+// requestTimeout bounds every call to the model, so that a stalled answer cannot hold a search until the
+// caller's deadline.
+const requestTimeout = 60 * time.Second
+
+// responseFormatKey is the call metadata key of the format set by WithResponseFormat. The "openai:" prefix
+// keeps the OpenAI client from forwarding it to the API as request metadata.
+const responseFormatKey = "openai:response_format"
+
+// Backend is the chat model shared by LLMSuggester and LLMAgent. Every langchaingo model satisfies it.
+type Backend interface {
+	GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error)
+}
+
+// ResponseFormat is a structured output format: the answer must be a JSON document matching Schema.
+type ResponseFormat struct {
+	// Name identifies the schema to the API.
+	Name string
+	// Schema is the JSON schema of the answer, sent as is so that keywords such as maxItems reach the API.
+	Schema map[string]any
+}
+
+// MarshalJSON encodes the format as the strict "json_schema" response format of OpenAI-compatible APIs.
+func (f *ResponseFormat) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"type": "json_schema",
+		"json_schema": map[string]any{
+			"name":   f.Name,
+			"strict": true,
+			"schema": f.Schema,
+		},
+	})
+}
+
+// WithResponseFormat constrains the answer of a single call to format. Backends built by NewBackend send it
+// as structured output where the API supports it and fall back to JSON mode otherwise.
+func WithResponseFormat(format *ResponseFormat) llms.CallOption {
+	return func(o *llms.CallOptions) {
+		if o.Metadata == nil {
+			o.Metadata = make(map[string]interface{})
+		}
+		o.Metadata[responseFormatKey] = format
+	}
+}
+
+// NewBackend builds the Backend selected by cfg.AIProvider. An empty provider selects the OpenAI-compatible
+// client, which also covers vendors such as Groq that expose the same API. Mistral is reached through its
+// OpenAI-compatible API with Mistral's safety prompt enabled on every request.
+func NewBackend(cfg Config) (Backend, error) {
+	httpClient := &http.Client{Timeout: requestTimeout}
+	switch provider := strings.ToLower(strings.TrimSpace(cfg.AIProvider)); provider {
+	case "", ProviderOpenAI:
+		opts := []openai.Option{
+			openai.WithToken(cfg.AIAPIKey),
+			openai.WithModel(cfg.AIModel),
+			openai.WithHTTPClient(chatRequestClient{next: httpClient}),
+		}
+		if cfg.AIEndpoint != "" {
+			opts = append(opts, openai.WithBaseURL(cfg.AIEndpoint))
+		}
+		model, err := openai.New(opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &backend{model: model, structured: true}, nil
+	case ProviderMistral:
+		endpoint := strings.TrimSuffix(cfg.AIEndpoint, "/")
+		if endpoint == "" {
+			endpoint = defaultMistralEndpoint
+		}
+		model, err := openai.New(
+			openai.WithToken(cfg.AIAPIKey),
+			openai.WithModel(cfg.AIModel),
+			openai.WithBaseURL(endpoint+"/v1"),
+			openai.WithHTTPClient(chatRequestClient{next: httpClient, safePrompt: true}),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return &backend{model: model, structured: true}, nil
+	case ProviderAnthropic:
+		opts := []anthropic.Option{
+			anthropic.WithToken(cfg.AIAPIKey),
+			anthropic.WithModel(cfg.AIModel),
+			anthropic.WithHTTPClient(httpClient),
+		}
+		if cfg.AIEndpoint != "" {
+			opts = append(opts, anthropic.WithBaseURL(cfg.AIEndpoint))
+		}
+		model, err := anthropic.New(opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &backend{model: model}, nil
+	case ProviderOllama:
+		opts := []ollama.Option{ollama.WithModel(cfg.AIModel), ollama.WithHTTPClient(httpClient)}
+		if cfg.AIEndpoint != "" {
+			opts = append(opts, ollama.WithServerURL(cfg.AIEndpoint))
+		}
+		model, err := ollama.New(opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &backend{model: model}, nil
+	default:
+		return nil, fmt.Errorf("unsupported AI provider %q", provider)
+	}
+}
+
+// backend applies the response format of WithResponseFormat to a langchaingo model. Structured backends
+// hand it to chatRequestClient through the request context; the others are asked for JSON mode instead.
+type backend struct {
+	model      llms.Model
+	structured bool
+}
+
+// responseFormatContextKey carries the response format of a call from backend to chatRequestClient.
+type responseFormatContextKey struct{}
+
+func (b *backend) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	var opts llms.CallOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+	if format, ok := opts.Metadata[responseFormatKey].(*ResponseFormat); ok && format != nil {
+		if b.structured {
+			ctx = context.WithValue(ctx, responseFormatContextKey{}, format)
+		} else {
+			options = append(options, llms.WithJSONMode())
+		}
+	}
+	return b.model.GenerateContent(ctx, messages, options...)
+}
+
+// chatRequestClient rewrites the JSON requests of the OpenAI-compatible client, which has no per-call
+// options for them: it sets the response format of the call, if any, and Mistral's "safe_prompt" flag.
+type chatRequestClient struct {
+	next       *http.Client
+	safePrompt bool
+}
+
+func (c chatRequestClient) Do(req *http.Request) (*http.Response, error) {
+	format, _ := req.Context().Value(responseFormatContextKey{}).(*ResponseFormat)
+	if req.Body == nil || req.Method != http.MethodPost || (format == nil && !c.safePrompt) {
+		return c.next.Do(req)
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("read request body: %w", err)
+	}
+
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(body, &payload); err == nil {
+		if format != nil {
+			if encoded, err := json.Marshal(format); err == nil {
+				payload["response_format"] = encoded
+			}
+		}
+		if c.safePrompt {
+			payload["safe_prompt"] = json.RawMessage("true")
+		}
+		if rewritten, err := json.Marshal(payload); err == nil {
+			body = rewritten
+		}
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return c.next.Do(req)
+}
@@ -0,0 +1,134 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// chatServer answers every chat completion with a fixed domain list and hands the decoded request to requests.
+func chatServer(t *testing.T, requests chan<- map[string]any) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]any
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("request body is not JSON: %v", err)
+		}
+		payload["path"] = r.URL.Path
+		requests <- payload
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"id":"1","object":"chat.completion","created":0,"model":"test","choices":[{"index":0,"finish_reason":"stop","message":{"role":"assistant","content":"{\"domains\":[\"brand.com\",\"brand.io\"]}"}}]}`)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestBackendStructuredOutput(t *testing.T) {
+	tests := []struct {
+		provider   string
+		endpoint   func(url string) string
+		path       string
+		safePrompt bool
+	}{
+		{provider: ProviderOpenAI, endpoint: func(url string) string { return url + "/v1" }, path: "/v1/chat/completions"},
+		{provider: ProviderMistral, endpoint: func(url string) string { return url }, path: "/v1/chat/completions", safePrompt: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			requests := make(chan map[string]any, 1)
+			srv := chatServer(t, requests)
+			backend, err := NewBackend(Config{
+				AIProvider: tt.provider,
+				AIEndpoint: tt.endpoint(srv.URL),
+				AIAPIKey:   "test",
+				AIModel:    "test",
+			})
+			if err != nil {
+				t.Fatalf("NewBackend: %v", err)
+			}
+
+			suggestions, err := NewLLMSuggester(backend).GenerateDomainSuggestions(context.Background(), AISuggestionRequest{Query: "brand", MaxResults: 1})
+			if err != nil {
+				t.Fatalf("GenerateDomainSuggestions: %v", err)
+			}
+			if len(suggestions) != 1 || suggestions[0].Domain != "brand.com" {
+				t.Errorf("suggestions = %+v, want only brand.com", suggestions)
+			}
+
+			req := <-requests
+			if req["path"] != tt.path {
+				t.Errorf("path = %v, want %s", req["path"], tt.path)
+			}
+			format, _ := req["response_format"].(map[string]any)
+			schema, _ := format["json_schema"].(map[string]any)
+			if format["type"] != "json_schema" || schema["name"] != "domain_suggestions" || schema["strict"] != true {
+				t.Errorf("response_format = %v, want the strict domain_suggestions schema", req["response_format"])
+			}
+			answer, _ := schema["schema"].(map[string]any)
+			properties, _ := answer["properties"].(map[string]any)
+			domains, _ := properties["domains"].(map[string]any)
+			if domains["minItems"] != 1.0 || domains["maxItems"] != 1.0 {
+				t.Errorf("domains schema = %v, want between 1 and MaxResults (1) items", domains)
+			}
+			if _, ok := req["metadata"]; ok {
+				t.Errorf("metadata = %v, want none", req["metadata"])
+			}
+			if got, _ := req["safe_prompt"].(bool); got != tt.safePrompt {
+				t.Errorf("safe_prompt = %v, want %v", req["safe_prompt"], tt.safePrompt)
+			}
+
+			// The agent shares the backend and must not inherit the suggester's format.
+			if _, err := backend.GenerateContent(context.Background(), []llms.MessageContent{
+				llms.TextParts(llms.ChatMessageTypeHuman, "hello"),
+			}); err != nil {
+				t.Fatalf("GenerateContent: %v", err)
+			}
+			req = <-requests
+			if _, ok := req["response_format"]; ok {
+				t.Errorf("response_format = %v on a call without one", req["response_format"])
+			}
+			if got, _ := req["safe_prompt"].(bool); got != tt.safePrompt {
+				t.Errorf("safe_prompt = %v, want %v", req["safe_prompt"], tt.safePrompt)
+			}
+		})
+	}
+}
+
+// recordingModel remembers the options of the last call.
+type recordingModel struct {
+	llms.Model
+	opts llms.CallOptions
+}
+
+func (m *recordingModel) GenerateContent(_ context.Context, _ []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	m.opts = llms.CallOptions{}
+	for _, opt := range options {
+		opt(&m.opts)
+	}
+	return &llms.ContentResponse{}, nil
+}
+
+func TestBackendFallsBackToJSONMode(t *testing.T) {
+	model := &recordingModel{}
+	b := &backend{model: model}
+	if _, err := b.GenerateContent(context.Background(), nil, WithResponseFormat(domainListResponseFormat(3))); err != nil {
+		t.Fatalf("GenerateContent: %v", err)
+	}
+	if !model.opts.JSONMode {
+		t.Error("JSON mode is off for a response format on a backend without structured output")
+	}
+
+	if _, err := b.GenerateContent(context.Background(), nil); err != nil {
+		t.Fatalf("GenerateContent: %v", err)
+	}
+	if model.opts.JSONMode {
+		t.Error("JSON mode is on for a call without a response format")
+	}
+}
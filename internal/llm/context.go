@@ -7,27 +7,35 @@ import (
 
 // ContextFields extracts and formats context fields for domain generation prompts
 type ContextFields struct {
-	PreferredTLDs string
-	ExcludedTLDs  string
-	BrandKeywords string
-	BusinessType  string
-	Location      string
+	PreferredTLDs  string
+	ExcludedTLDs   string
+	BrandKeywords  string
+	BusinessType   string
+	Location       string
+	Tone           string
+	BannedWords    string
+	TargetAudience string
+	Budget         string
 }
 
 // ExtractContextFields pulls known context fields from the context map
 func ExtractContextFields(ctx map[string]interface{}) ContextFields {
 	return ContextFields{
-		PreferredTLDs: stringFromContext(ctx, "preferred_tlds"),
-		ExcludedTLDs:  stringFromContext(ctx, "excluded_tlds"),
-		BrandKeywords: stringFromContext(ctx, "brand_keywords"),
-		BusinessType:  stringFromContext(ctx, "business_type"),
-		Location:      stringFromContext(ctx, "location"),
+		PreferredTLDs:  stringFromContext(ctx, "preferred_tlds"),
+		ExcludedTLDs:   stringFromContext(ctx, "excluded_tlds"),
+		BrandKeywords:  stringFromContext(ctx, "brand_keywords"),
+		BusinessType:   stringFromContext(ctx, "business_type"),
+		Location:       stringFromContext(ctx, "location"),
+		Tone:           stringFromContext(ctx, "tone"),
+		BannedWords:    stringFromContext(ctx, "banned_words"),
+		TargetAudience: stringFromContext(ctx, "target_audience"),
+		Budget:         stringFromContext(ctx, "budget"),
 	}
 }
 
 // FormatContextSection formats context fields into a readable section for prompts
 func (cf *ContextFields) FormatContextSection() string {
-	contextLines := make([]string, 0, 5)
+	contextLines := make([]string, 0, 9)
 
 	if cf.PreferredTLDs != "" {
 		contextLines = append(contextLines, fmt.Sprintf("- Preferred TLDs: %s", cf.PreferredTLDs))
@@ -41,9 +49,21 @@ func (cf *ContextFields) FormatContextSection() string {
 	if cf.Location != "" {
 		contextLines = append(contextLines, fmt.Sprintf("- Location focus: %s", cf.Location))
 	}
+	if cf.TargetAudience != "" {
+		contextLines = append(contextLines, fmt.Sprintf("- Target audience: %s", cf.TargetAudience))
+	}
+	if cf.Tone != "" {
+		contextLines = append(contextLines, fmt.Sprintf("- Tone: %s", toneGuidance(cf.Tone)))
+	}
 	if cf.BrandKeywords != "" {
 		contextLines = append(contextLines, fmt.Sprintf("- Brand keywords to include: %s", cf.BrandKeywords))
 	}
+	if cf.BannedWords != "" {
+		contextLines = append(contextLines, fmt.Sprintf("- Never use these words in any name: %s", cf.BannedWords))
+	}
+	if cf.Budget != "" {
+		contextLines = append(contextLines, fmt.Sprintf("- Budget: %s; favour TLDs that are usually priced within it", cf.Budget))
+	}
 
 	if len(contextLines) == 0 {
 		return "- No additional constraints were provided."
@@ -58,7 +78,43 @@ func (cf *ContextFields) HasContext() bool {
 		cf.ExcludedTLDs != "" ||
 		cf.BrandKeywords != "" ||
 		cf.BusinessType != "" ||
-		cf.Location != ""
+		cf.Location != "" ||
+		cf.Tone != "" ||
+		cf.BannedWords != "" ||
+		cf.TargetAudience != "" ||
+		cf.Budget != ""
+}
+
+// toneGuidance explains a tone to the model.
+func toneGuidance(tone string) string {
+	switch tone {
+	case "playful":
+		return "playful - fun, catchy, may use wordplay"
+	case "corporate":
+		return "corporate - serious, trustworthy, established"
+	case "technical":
+		return "technical - precise, engineering-minded, may use tech jargon"
+	default:
+		return tone
+	}
+}
+
+// scriptRule tells the model which characters it may use in domain labels
+func scriptRule(idn bool) string {
+	if idn {
+		return "- Internationalized domain names are welcome: when the request is in another language, write labels in its native script (e.g. ü, ş, Arabic letters) and pick TLDs that accept them."
+	}
+	return "- Use only ASCII letters, digits and hyphens in domain labels."
+}
+
+// currencyRule tells the model which currency the buyer pays in, so that it favours TLDs that are
+// affordable when quoted in it.
+func currencyRule(currency string) string {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		return ""
+	}
+	return fmt.Sprintf("\n- Prices are quoted in %s; favour TLDs that are commonly sold and reasonably priced in that currency.", currency)
 }
 
 // stringFromContext safely extracts a string value from a context map
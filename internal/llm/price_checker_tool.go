@@ -2,67 +2,32 @@ package llm
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"strconv"
-	"sync"
 
-	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
 	"github.com/olaysco/domain-search-llm/internal/provider"
 	"github.com/tmc/langchaingo/llms"
 )
 
 type PriceCheckerTool struct {
-	provider provider.PriceProvider
+	provider provider.PriceSearchProvider
 }
 
-func NewPriceCheckerTool(provider provider.PriceProvider) *PriceCheckerTool {
+func NewPriceCheckerTool(provider provider.PriceSearchProvider) *PriceCheckerTool {
 	return &PriceCheckerTool{
 		provider,
 	}
 }
 
 func (pct *PriceCheckerTool) Call(ctx context.Context, domain string) (string, error) {
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
-
-	var wg sync.WaitGroup
-	wg.Add(1)
-
-	respCh := make(chan *domainsearchv1.Price, 1)
-	errCh := make(chan error, 1)
-
-	go func() {
-		defer wg.Done()
-		err := pct.provider.StreamPrices(ctx, domain, func(resp *domainsearchv1.SearchPricesResponse) error {
-			if resp == nil {
-				return nil
-			}
-			if price := resp.GetPrice(); price != nil {
-				respCh <- price
-			}
-			return nil
-		})
-		if err != nil && !errors.Is(err, context.Canceled) {
-			select {
-			case errCh <- err:
-			default:
-			}
-			cancel()
-		}
-
-	}()
-
-	wg.Wait()
-	select {
-	case respn := <-respCh:
-		return strconv.FormatFloat(float64(respn.Cost), 'f', 2, 32), nil
-	case err := <-errCh:
-		fmt.Println(err)
-		return "0", err
-	default:
-		return "0", fmt.Errorf("unable to fetch price for %s", domain)
+	args := parseToolArguments(domain)
+	prices, err := pct.provider.GetDomainPrice(ctx, args.Name, args.Currency)
+	if err != nil {
+		return "0", fmt.Errorf("price lookup for %s: %w", args.Name, err)
+	}
+	if len(prices) == 0 {
+		return "0", fmt.Errorf("unable to fetch price for %s", args.Name)
 	}
+	return prices[0].Cost.StringFixed(2) + " " + prices[0].Currency, nil
 }
 
 func (pct *PriceCheckerTool) Name() string {
@@ -81,6 +46,10 @@ func (pct *PriceCheckerTool) Parameters() map[string]any {
 				"type":        "string",
 				"description": "The full domain name and tld to get price for, e.g. escobar.com",
 			},
+			"currency": map[string]any{
+				"type":        "string",
+				"description": "ISO 4217 currency code to quote the price in, e.g. EUR. Defaults to USD",
+			},
 		},
 		"required": []string{"name"},
 	}
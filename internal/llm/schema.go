@@ -0,0 +1,79 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// domainListSchema is the JSON schema of the suggester's answer: at most maxResults domain names.
+func domainListSchema(maxResults int) string {
+	return renderSchema(domainListSchemaObject(maxResults))
+}
+
+// domainListResponseFormat is domainListSchema as a strict structured output format, so that the API itself
+// bounds the answer to maxResults domains.
+func domainListResponseFormat(maxResults int) *ResponseFormat {
+	return &ResponseFormat{Name: "domain_suggestions", Schema: domainListSchemaObject(maxResults)}
+}
+
+func domainListSchemaObject(maxResults int) map[string]any {
+	return map[string]any{
+		"type":     "object",
+		"required": []string{"domains"},
+		"properties": map[string]any{
+			"domains": map[string]any{
+				"type":     "array",
+				"minItems": 1,
+				"maxItems": maxResults,
+				"items":    map[string]any{"type": "string"},
+			},
+		},
+		"additionalProperties": false,
+	}
+}
+
+// agentAnswerSchema is the JSON schema of the agent's final answer: at most maxResults suggestions with the
+// tool data the agent collected.
+func agentAnswerSchema(maxResults int) string {
+	return renderSchema(map[string]any{
+		"type":     "object",
+		"required": []string{"domains"},
+		"properties": map[string]any{
+			"domains": map[string]any{
+				"type":     "array",
+				"minItems": 1,
+				"maxItems": maxResults,
+				"items": map[string]any{
+					"type":     "object",
+					"required": []string{"domain", "reasoning"},
+					"properties": map[string]any{
+						"domain":          map[string]any{"type": "string"},
+						"relevance_score": map[string]any{"type": "number"},
+						"available":       map[string]any{"type": "boolean"},
+						"price":           map[string]any{"type": "number"},
+						"currency":        map[string]any{"type": "string"},
+						"renewal_price":   map[string]any{"type": "number"},
+						"promotion":       map[string]any{"type": "boolean"},
+						"reasoning":       map[string]any{"type": "string"},
+					},
+				},
+			},
+			"final_message": map[string]any{"type": "string"},
+		},
+	})
+}
+
+func renderSchema(schema map[string]any) string {
+	data, _ := json.MarshalIndent(schema, "", "  ")
+	return string(data)
+}
+
+// excludeRule asks the model not to repeat domains suggested in earlier rounds. It is empty or starts a new
+// line, so it can follow another rule directly.
+func excludeRule(exclude []string) string {
+	if len(exclude) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\n- Do not suggest any of these domains again: %s.", strings.Join(exclude, ", "))
+}
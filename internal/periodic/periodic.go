@@ -0,0 +1,30 @@
+package periodic
+
+import (
+	"context"
+	"time"
+)
+
+// Run calls fn every interval until ctx is cancelled. Errors returned by fn are passed to onError, which may
+// be nil, and do not stop the loop. A tick that races with the cancellation is dropped, so fn is never called
+// once ctx is done. Run returns immediately when interval is not positive.
+func Run(ctx context.Context, interval time.Duration, fn func(context.Context) error, onError func(error)) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if ctx.Err() != nil {
+				return
+			}
+			if err := fn(ctx); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
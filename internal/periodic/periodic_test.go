@@ -0,0 +1,43 @@
+package periodic
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunReportsErrorsAndStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls, failures := 0, 0
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Run(ctx, time.Millisecond, func(context.Context) error {
+			calls++
+			if calls == 3 {
+				cancel()
+			}
+			if calls%2 == 1 {
+				return errors.New("refresh failed")
+			}
+			return nil
+		}, func(error) { failures++ })
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after cancellation")
+	}
+	if calls != 3 || failures != 2 {
+		t.Errorf("calls = %d, failures = %d; want 3 and 2", calls, failures)
+	}
+}
+
+func TestRunWithoutInterval(t *testing.T) {
+	Run(context.Background(), 0, func(context.Context) error {
+		t.Error("fn called without an interval")
+		return nil
+	}, nil)
+}
@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
+)
+
+// Supported values for AggregatorConfig.Selection.
+const (
+	// SelectCheapest picks the lowest registration cost, preferring quotes in the requested currency.
+	SelectCheapest = "cheapest"
+	// SelectPriority picks the quote of the first source, in configuration order, that priced the domain.
+	SelectPriority = "priority"
+)
+
+const defaultSourceTimeout = 10 * time.Second
+
+// PriceSource is a named PriceProvider taking part in aggregation.
+type PriceSource struct {
+	Name     string
+	Provider PriceProvider
+}
+
+// AggregatorConfig tunes the Aggregator. Zero values select the defaults.
+type AggregatorConfig struct {
+	// Selection is SelectCheapest (the default) or SelectPriority.
+	Selection string
+	// SourceTimeout bounds each source; quotes received before it expires are still used.
+	SourceTimeout time.Duration
+	// OnError, when set, is told about every source that failed or timed out.
+	OnError func(source string, err error)
+}
+
+// Aggregator is a PriceProvider that asks every source in parallel and returns one price per domain,
+// selected according to the configuration and labelled with the source that quoted it. A failing source is
+// skipped as long as another one answers.
+type Aggregator struct {
+	sources []PriceSource
+	cfg     AggregatorConfig
+}
+
+// NewAggregator builds an Aggregator over sources, listed by priority.
+func NewAggregator(sources []PriceSource, cfg AggregatorConfig) (*Aggregator, error) {
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("at least one price source is required")
+	}
+	switch cfg.Selection {
+	case "":
+		cfg.Selection = SelectCheapest
+	case SelectCheapest, SelectPriority:
+	default:
+		return nil, fmt.Errorf("unsupported price selection %q", cfg.Selection)
+	}
+	if cfg.SourceTimeout <= 0 {
+		cfg.SourceTimeout = defaultSourceTimeout
+	}
+	return &Aggregator{sources: sources, cfg: cfg}, nil
+}
+
+// StreamPrices implements PriceProvider. The handler is called once all sources answered or timed out.
+func (a *Aggregator) StreamPrices(ctx context.Context, req string, currency string, handler PriceStreamHandler) error {
+	if handler == nil {
+		return fmt.Errorf("price stream handler cannot be nil")
+	}
+	return a.StreamPricesBatch(ctx, []string{req}, currency, func(_ string, resp *domainsearchv1.SearchPricesResponse) error {
+		return handler(resp)
+	})
+}
+
+// GetDomainPrice implements PriceSearchProvider by collecting the aggregated price of the domain.
+func (a *Aggregator) GetDomainPrice(ctx context.Context, query string, currency string) ([]*Price, error) {
+	return CollectPrices(ctx, a, query, currency)
+}
+
+// StreamPricesBatch implements BatchPriceProvider, forwarding the batch to sources that support it.
+func (a *Aggregator) StreamPricesBatch(ctx context.Context, domains []string, currency string, handler BatchPriceStreamHandler) error {
+	if handler == nil {
+		return fmt.Errorf("price stream handler cannot be nil")
+	}
+	currency = normalizeCurrency(currency)
+
+	quotes := make([]map[string]*domainsearchv1.SearchPricesResponse, len(a.sources))
+	errs := make([]error, len(a.sources))
+	var wg sync.WaitGroup
+	for i, source := range a.sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sourceCtx, cancel := context.WithTimeout(ctx, a.cfg.SourceTimeout)
+			defer cancel()
+			quotes[i], errs[i] = collectQuotes(sourceCtx, source.Provider, domains, currency)
+			if errs[i] != nil && a.cfg.OnError != nil && ctx.Err() == nil {
+				a.cfg.OnError(source.Name, errs[i])
+			}
+		}()
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for _, domain := range domains {
+		if resp := a.pick(domain, currency, quotes); resp != nil {
+			if err := handler(domain, resp); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, err := range errs {
+		if err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("all price sources failed: %w", errors.Join(errs...))
+}
+
+// pick selects the price of a domain among the quotes of every source. When no source priced it, the first
+// error answer is returned instead.
+func (a *Aggregator) pick(domain string, currency string, quotes []map[string]*domainsearchv1.SearchPricesResponse) *domainsearchv1.SearchPricesResponse {
+	var (
+		best      *domainsearchv1.SearchPricesResponse
+		bestIndex int
+		fallback  *domainsearchv1.SearchPricesResponse
+	)
+	for i, sourceQuotes := range quotes {
+		resp := sourceQuotes[domain]
+		if resp == nil {
+			continue
+		}
+		if resp.GetPrice() == nil {
+			if fallback == nil {
+				fallback = resp
+			}
+			continue
+		}
+		if best == nil || a.better(resp.GetPrice(), best.GetPrice(), currency) {
+			best, bestIndex = resp, i
+		}
+	}
+	if best == nil {
+		return fallback
+	}
+	best.GetPrice().Provider = a.sources[bestIndex].Name
+	return best
+}
+
+// better reports whether candidate beats current. Sources are visited by priority, so ties keep the
+// earlier source.
+func (a *Aggregator) better(candidate, current *domainsearchv1.Price, currency string) bool {
+	if a.cfg.Selection == SelectPriority {
+		return false
+	}
+	candidateRequested := strings.EqualFold(candidate.GetCurrency(), currency)
+	currentRequested := strings.EqualFold(current.GetCurrency(), currency)
+	if candidateRequested != currentRequested {
+		return candidateRequested
+	}
+	if !strings.EqualFold(candidate.GetCurrency(), current.GetCurrency()) {
+		return false
+	}
+	return CostOf(candidate).LessThan(CostOf(current))
+}
+
+// collectQuotes gathers the first price, or failing that the first error answer, that a provider returns
+// for each domain. Quotes received before an error are returned along with it.
+func collectQuotes(ctx context.Context, provider PriceProvider, domains []string, currency string) (map[string]*domainsearchv1.SearchPricesResponse, error) {
+	var mu sync.Mutex
+	quotes := make(map[string]*domainsearchv1.SearchPricesResponse, len(domains))
+	record := func(domain string, resp *domainsearchv1.SearchPricesResponse) error {
+		if resp == nil || resp.GetResponse() == nil {
+			return nil
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if current, ok := quotes[domain]; !ok || (current.GetPrice() == nil && resp.GetPrice() != nil) {
+			quotes[domain] = resp
+		}
+		return nil
+	}
+
+	if batcher, ok := provider.(BatchPriceProvider); ok {
+		err := batcher.StreamPricesBatch(ctx, domains, currency, record)
+		mu.Lock()
+		defer mu.Unlock()
+		return quotes, err
+	}
+
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, len(domains))
+	)
+	for i, domain := range domains {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = provider.StreamPrices(ctx, domain, currency, func(resp *domainsearchv1.SearchPricesResponse) error {
+				return record(domain, resp)
+			})
+		}()
+	}
+	wg.Wait()
+	return quotes, errors.Join(errs...)
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// quote is the price a fakeSource gives for one domain.
+type quote struct {
+	currency string
+	cost     string
+}
+
+// fakeSource prices the domains it has quotes for, answers NotFound for the others and fails every call
+// with err when it is set.
+type fakeSource struct {
+	quotes map[string]quote
+	err    error
+}
+
+func (s fakeSource) StreamPrices(_ context.Context, domain string, _ string, handler PriceStreamHandler) error {
+	if s.err != nil {
+		return s.err
+	}
+	q, ok := s.quotes[domain]
+	if !ok {
+		return handler(&domainsearchv1.SearchPricesResponse{
+			Response: &domainsearchv1.SearchPricesResponse_Error{Error: status.New(codes.NotFound, "not listed").Proto()},
+		})
+	}
+	price := &domainsearchv1.Price{Domain: domain}
+	cost := decimal.RequireFromString(q.cost)
+	SetAmounts(price, q.currency, cost, cost)
+	return handler(&domainsearchv1.SearchPricesResponse{
+		Response: &domainsearchv1.SearchPricesResponse_Price{Price: price},
+	})
+}
+
+// aggregatedPrices returns the source and cost picked for every domain, or the status code of its error answer.
+func aggregatedPrices(t *testing.T, a *Aggregator, domains ...string) map[string]string {
+	t.Helper()
+	got := make(map[string]string)
+	err := a.StreamPricesBatch(context.Background(), domains, "USD", func(domain string, resp *domainsearchv1.SearchPricesResponse) error {
+		if price := resp.GetPrice(); price != nil {
+			got[domain] = price.GetProvider() + " " + CostOf(price).String() + " " + price.GetCurrency()
+		} else {
+			got[domain] = codes.Code(resp.GetError().GetCode()).String()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamPricesBatch: %v", err)
+	}
+	return got
+}
+
+func TestAggregatorSelection(t *testing.T) {
+	sources := []PriceSource{
+		{Name: "primary", Provider: fakeSource{quotes: map[string]quote{
+			"brand.com": {"USD", "12.5"},
+			"brand.io":  {"USD", "30"},
+			"brand.ai":  {"EUR", "50"},
+		}}},
+		{Name: "secondary", Provider: fakeSource{quotes: map[string]quote{
+			"brand.com": {"USD", "9.99"},
+			"brand.io":  {"EUR", "20"},
+			"brand.ai":  {"USD", "80"},
+			"brand.de":  {"USD", "6"},
+		}}},
+		{Name: "tertiary", Provider: fakeSource{quotes: map[string]quote{
+			"brand.com": {"USD", "9.99"},
+		}}},
+	}
+	tests := []struct {
+		selection string
+		want      map[string]string
+	}{
+		{
+			selection: SelectCheapest,
+			want: map[string]string{
+				"brand.com": "secondary 9.99 USD",
+				"brand.io":  "primary 30 USD",
+				"brand.ai":  "secondary 80 USD",
+				"brand.de":  "secondary 6 USD",
+				"brand.xyz": "NotFound",
+			},
+		},
+		{
+			selection: SelectPriority,
+			want: map[string]string{
+				"brand.com": "primary 12.5 USD",
+				"brand.io":  "primary 30 USD",
+				"brand.ai":  "primary 50 EUR",
+				"brand.de":  "secondary 6 USD",
+				"brand.xyz": "NotFound",
+			},
+		},
+	}
+	for _, tt := range tests {
+		a, err := NewAggregator(sources, AggregatorConfig{Selection: tt.selection})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := aggregatedPrices(t, a, "brand.com", "brand.io", "brand.ai", "brand.de", "brand.xyz")
+		for domain, want := range tt.want {
+			if got[domain] != want {
+				t.Errorf("%s: %s = %q, want %q", tt.selection, domain, got[domain], want)
+			}
+		}
+	}
+}
+
+func TestAggregatorSkipsFailingSources(t *testing.T) {
+	down := errors.New("connection refused")
+	var failed []string
+	a, err := NewAggregator([]PriceSource{
+		{Name: "primary", Provider: fakeSource{err: down}},
+		{Name: "secondary", Provider: fakeSource{quotes: map[string]quote{"brand.com": {"USD", "9.99"}}}},
+	}, AggregatorConfig{OnError: func(source string, err error) { failed = append(failed, source) }})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := aggregatedPrices(t, a, "brand.com"); got["brand.com"] != "secondary 9.99 USD" {
+		t.Errorf("brand.com = %q, want the secondary quote", got["brand.com"])
+	}
+	if len(failed) != 1 || failed[0] != "primary" {
+		t.Errorf("reported failures = %v, want [primary]", failed)
+	}
+
+	a, err = NewAggregator([]PriceSource{{Name: "primary", Provider: fakeSource{err: down}}}, AggregatorConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = a.StreamPrices(context.Background(), "brand.com", "USD", func(*domainsearchv1.SearchPricesResponse) error { return nil })
+	if !errors.Is(err, down) {
+		t.Errorf("StreamPrices error = %v, want the source failure", err)
+	}
+}
+
+func TestNewAggregatorRejectsUnknownSelection(t *testing.T) {
+	if _, err := NewAggregator([]PriceSource{{Name: "primary", Provider: fakeSource{}}}, AggregatorConfig{Selection: "random"}); err == nil {
+		t.Error("NewAggregator accepted an unknown selection")
+	}
+	if _, err := NewAggregator(nil, AggregatorConfig{}); err == nil {
+		t.Error("NewAggregator accepted no sources")
+	}
+}
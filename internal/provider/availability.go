@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+
+	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
+)
+
+// Availability is the registration state of a domain as resolved by an AvailabilityChecker.
+type Availability int
+
+const (
+	// AvailabilityUnknown means the state could not be determined.
+	AvailabilityUnknown Availability = iota
+	// AvailabilityAvailable means the domain is not registered.
+	AvailabilityAvailable
+	// AvailabilityTaken means the domain is already registered.
+	AvailabilityTaken
+)
+
+func (a Availability) String() string {
+	switch a {
+	case AvailabilityAvailable:
+		return "available"
+	case AvailabilityTaken:
+		return "taken"
+	default:
+		return "unknown"
+	}
+}
+
+// Status maps the availability onto its API representation.
+func (a Availability) Status() domainsearchv1.AvailabilityStatus {
+	switch a {
+	case AvailabilityAvailable:
+		return domainsearchv1.AvailabilityStatus_AVAILABILITY_STATUS_AVAILABLE
+	case AvailabilityTaken:
+		return domainsearchv1.AvailabilityStatus_AVAILABILITY_STATUS_TAKEN
+	default:
+		return domainsearchv1.AvailabilityStatus_AVAILABILITY_STATUS_UNKNOWN
+	}
+}
+
+// AvailabilityChecker resolves whether a domain can be registered.
+type AvailabilityChecker interface {
+	CheckAvailability(ctx context.Context, domain string) (Availability, error)
+}
+
+// ApplyAvailability writes the resolved availability onto a price payload.
+func ApplyAvailability(price *domainsearchv1.Price, availability Availability) {
+	if price == nil {
+		return
+	}
+	price.Availability = availability == AvailabilityAvailable
+	price.AvailabilityStatus = availability.Status()
+}
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// AvailabilityCacheConfig tunes the availability cache. Zero values select the defaults.
+type AvailabilityCacheConfig struct {
+	// AvailableTTL is how long an available answer is trusted; kept short since names get registered.
+	AvailableTTL time.Duration
+	// TakenTTL is how long a taken answer is trusted.
+	TakenTTL time.Duration
+}
+
+const (
+	defaultAvailableTTL   = 5 * time.Minute
+	defaultTakenTTL       = time.Hour
+	availabilityCacheKind = "availability"
+)
+
+// CachedAvailabilityChecker remembers the answers of another AvailabilityChecker in a Cache. Unknown results
+// and lookup errors are never cached.
+type CachedAvailabilityChecker struct {
+	next    AvailabilityChecker
+	backend Cache
+	cfg     AvailabilityCacheConfig
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+	errors atomic.Uint64
+}
+
+// NewCachedAvailabilityChecker wraps next with backend.
+func NewCachedAvailabilityChecker(next AvailabilityChecker, backend Cache, cfg AvailabilityCacheConfig) *CachedAvailabilityChecker {
+	if cfg.AvailableTTL <= 0 {
+		cfg.AvailableTTL = defaultAvailableTTL
+	}
+	if cfg.TakenTTL <= 0 {
+		cfg.TakenTTL = defaultTakenTTL
+	}
+	return &CachedAvailabilityChecker{next: next, backend: backend, cfg: cfg}
+}
+
+// CheckAvailability implements AvailabilityChecker.
+func (c *CachedAvailabilityChecker) CheckAvailability(ctx context.Context, domain string) (Availability, error) {
+	key := domainCacheKey(availabilityCacheKind, domain, "")
+	value, ok, err := c.backend.Get(ctx, key)
+	if err != nil {
+		c.errors.Add(1)
+	}
+	if ok {
+		if n, err := strconv.Atoi(string(value)); err == nil {
+			c.hits.Add(1)
+			return Availability(n), nil
+		}
+		c.errors.Add(1)
+	}
+	c.misses.Add(1)
+
+	availability, err := c.next.CheckAvailability(ctx, domain)
+	if err != nil {
+		return availability, err
+	}
+	if availability == AvailabilityUnknown {
+		return availability, nil
+	}
+	ttl := c.cfg.TakenTTL
+	if availability == AvailabilityAvailable {
+		ttl = c.cfg.AvailableTTL
+	}
+	if err := c.backend.Set(ctx, key, []byte(strconv.Itoa(int(availability))), ttl); err != nil {
+		c.errors.Add(1)
+	}
+	return availability, nil
+}
+
+// InvalidateDomain drops the cached answer for a domain.
+func (c *CachedAvailabilityChecker) InvalidateDomain(ctx context.Context, domain string) (int, error) {
+	return c.backend.DeletePrefix(ctx, domainCacheKey(availabilityCacheKind, domain, ""))
+}
+
+// InvalidateTLD drops the cached answers for every domain under a TLD or public suffix, including the
+// suffixes under a TLD.
+func (c *CachedAvailabilityChecker) InvalidateTLD(ctx context.Context, tld string) (int, error) {
+	return deleteSuffix(ctx, c.backend, availabilityCacheKind, tld)
+}
+
+// Clear drops every cached answer.
+func (c *CachedAvailabilityChecker) Clear(ctx context.Context) (int, error) {
+	return c.backend.DeletePrefix(ctx, availabilityCacheKind+"|")
+}
+
+// Stats returns the lookup counters.
+func (c *CachedAvailabilityChecker) Stats() CacheLookupStats {
+	return CacheLookupStats{Hits: c.hits.Load(), Misses: c.misses.Load(), Errors: c.errors.Load()}
+}
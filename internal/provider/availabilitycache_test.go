@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// countingChecker answers every lookup with the same result and counts how often it was asked.
+type countingChecker struct {
+	availability Availability
+	err          error
+	calls        int
+}
+
+func (c *countingChecker) CheckAvailability(context.Context, string) (Availability, error) {
+	c.calls++
+	return c.availability, c.err
+}
+
+func TestCachedAvailabilityChecker(t *testing.T) {
+	lookupFailed := errors.New("registry unavailable")
+	tests := []struct {
+		name      string
+		next      *countingChecker
+		wantCalls int
+	}{
+		{name: "available", next: &countingChecker{availability: AvailabilityAvailable}, wantCalls: 1},
+		{name: "taken", next: &countingChecker{availability: AvailabilityTaken}, wantCalls: 1},
+		{name: "unknown", next: &countingChecker{availability: AvailabilityUnknown}, wantCalls: 2},
+		{name: "error", next: &countingChecker{availability: AvailabilityUnknown, err: lookupFailed}, wantCalls: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			checker := NewCachedAvailabilityChecker(tt.next, NewMemoryCache(0), AvailabilityCacheConfig{})
+			for i := 0; i < 2; i++ {
+				got, err := checker.CheckAvailability(ctx, "brand.com")
+				if got != tt.next.availability || !errors.Is(err, tt.next.err) {
+					t.Fatalf("lookup %d = %v, %v; want %v, %v", i+1, got, err, tt.next.availability, tt.next.err)
+				}
+			}
+			if tt.next.calls != tt.wantCalls {
+				t.Errorf("checker asked %d times, want %d", tt.next.calls, tt.wantCalls)
+			}
+		})
+	}
+}
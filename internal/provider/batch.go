@@ -0,0 +1,235 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
+	pricepb "github.com/openprovider/contracts/v2/product/price"
+	"google.golang.org/protobuf/proto"
+)
+
+// BatchPriceStreamHandler receives each response of a batched lookup together with the domain it prices.
+type BatchPriceStreamHandler func(domain string, resp *domainsearchv1.SearchPricesResponse) error
+
+// BatchPriceProvider is implemented by providers that can price several domains with fewer upstream calls
+// than one per domain.
+type BatchPriceProvider interface {
+	StreamPricesBatch(ctx context.Context, domains []string, currency string, handler BatchPriceStreamHandler) error
+}
+
+// StreamPricesBatch prices the domains, grouping those that share a second-level label (brand.com,
+// brand.io, brand.ai) into a single upstream call listing all their TLDs. Cached prices are served first,
+// and domains with a lookup of their own in flight join it. Concurrent batches asking for the same label,
+// TLDs and currency share one upstream call. Handler calls are serialized; the first error cancels the
+// remaining lookups and is returned.
+func (p *PriceService) StreamPricesBatch(ctx context.Context, domains []string, currency string, handler BatchPriceStreamHandler) error {
+	if handler == nil {
+		return fmt.Errorf("price stream handler cannot be nil")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	currency = normalizeCurrency(currency)
+	var mu sync.Mutex
+	emit := func(domain string, resp *domainsearchv1.SearchPricesResponse) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return handler(domain, resp)
+	}
+
+	groups := make(map[string][]string)
+	var (
+		labels []string
+		single []string
+	)
+	for _, domain := range domains {
+		if cached, ok := p.cache.Get(ctx, domain, currency); ok {
+			if err := emit(domain, cached); err != nil {
+				return err
+			}
+			continue
+		}
+		if p.flights.running(priceCacheKey(domain, currency)) {
+			single = append(single, domain)
+			continue
+		}
+		label, _ := extractTLD(domain)
+		if _, ok := groups[label]; !ok {
+			labels = append(labels, label)
+		}
+		groups[label] = append(groups[label], domain)
+	}
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+	stream := func(domain string) {
+		defer wg.Done()
+		if err := p.StreamPrices(ctx, domain, currency, func(resp *domainsearchv1.SearchPricesResponse) error {
+			return emit(domain, resp)
+		}); err != nil {
+			fail(err)
+		}
+	}
+	for _, domain := range single {
+		wg.Add(1)
+		go stream(domain)
+	}
+	for _, label := range labels {
+		group := groups[label]
+		if len(group) == 1 || label == "" {
+			for _, domain := range group {
+				wg.Add(1)
+				go stream(domain)
+			}
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := p.streamPriceGroup(ctx, label, group, currency, emit); err != nil {
+				fail(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil && !errors.Is(firstErr, context.Canceled) {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// streamPriceGroup prices the domains of a label through a flight shared by every concurrent lookup of the
+// same label, TLDs and currency. The flight tags each response with the domain it answers.
+func (p *PriceService) streamPriceGroup(ctx context.Context, label string, domains []string, currency string, emit BatchPriceStreamHandler) error {
+	byName := make(map[string]string, len(domains))
+	for _, domain := range domains {
+		byName[normalizeCacheDomain(domain)] = domain
+	}
+	key := priceGroupKey(label, domains, currency)
+	flight := p.flights.join(ctx, key, func(ctx context.Context, f *priceFlight) error {
+		return p.fetchPriceGroup(ctx, label, domains, currency, f)
+	})
+	defer p.flights.leave(key, flight)
+	return flight.relay(ctx, func(resp *domainsearchv1.SearchPricesResponse) error {
+		domain, ok := byName[normalizeCacheDomain(resp.GetDomain())]
+		if !ok {
+			return nil
+		}
+		return emit(domain, resp)
+	})
+}
+
+// priceGroupKey identifies the flight of a label priced under a set of TLDs in a currency.
+func priceGroupKey(label string, domains []string, currency string) string {
+	tlds := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		_, tld := extractTLD(domain)
+		tlds = append(tlds, tld)
+	}
+	sort.Strings(tlds)
+	return priceCacheKind + "-group|" + label + "|" + strings.Join(tlds, ",") + "|" + normalizeCurrency(currency)
+}
+
+// fetchPriceGroup runs one upstream stream for every domain of a label and publishes each price to the
+// flight for the domain named by its PriceData; prices of other domains are dropped. Prices and in-band
+// errors are cached like those of fetchPrices. An error response concerns the whole request and answers
+// every domain not priced yet. Domains the stream left unanswered, for instance because their prices came
+// without a domain name, are looked up on their own. A failed upstream call is published as an error
+// answer for the domains not priced yet.
+func (p *PriceService) fetchPriceGroup(ctx context.Context, label string, domains []string, currency string, flight *priceFlight) error {
+	byName := make(map[string]string, len(domains))
+	tlds := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		byName[normalizeCacheDomain(domain)] = domain
+		_, tld := extractTLD(domain)
+		tlds = append(tlds, tld)
+	}
+	priced := make(map[string]bool, len(domains))
+	answered := make(map[string]bool, len(domains))
+	publish := func(domain string, resp *domainsearchv1.SearchPricesResponse) {
+		resp.Domain = domain
+		answered[domain] = true
+		flight.publish(resp)
+	}
+	fail := func(err error) error {
+		return p.publishFailure(ctx, err, func(resp *domainsearchv1.SearchPricesResponse) {
+			for _, domain := range domains {
+				if !priced[domain] {
+					publish(domain, proto.Clone(resp).(*domainsearchv1.SearchPricesResponse))
+				}
+			}
+		})
+	}
+
+	stream, err := p.client.SearchPriceFastCheckout(ctx, priceSearchRequest(label, tlds, currency))
+	if err != nil {
+		return fail(fmt.Errorf("price service search: %w", err))
+	}
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fail(fmt.Errorf("price service stream recv: %w", err))
+		}
+
+		if data, ok := msg.GetResponse().(*pricepb.SearchPricesResponse_Price); ok {
+			domain, ok := byName[normalizeCacheDomain(strings.TrimSuffix(data.Price.GetDomain(), "."))]
+			if !ok || priced[domain] {
+				continue
+			}
+			resp := fromPriceSearchResponse(domain, msg)
+			if resp == nil {
+				continue
+			}
+			if err := p.convert(ctx, resp.GetPrice(), currency); err != nil {
+				return err
+			}
+			p.cache.Set(ctx, domain, currency, resp)
+			priced[domain] = true
+			publish(domain, resp)
+			continue
+		}
+
+		for _, domain := range domains {
+			if priced[domain] {
+				continue
+			}
+			if resp := fromPriceSearchResponse(domain, msg); resp.GetError() != nil {
+				p.cache.Set(ctx, domain, currency, resp)
+				publish(domain, resp)
+			}
+		}
+	}
+
+	for _, domain := range domains {
+		if answered[domain] {
+			continue
+		}
+		if err := p.StreamPrices(ctx, domain, currency, func(resp *domainsearchv1.SearchPricesResponse) error {
+			resp.Domain = domain
+			flight.publish(resp)
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
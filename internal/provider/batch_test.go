@@ -0,0 +1,273 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
+	pricepb "github.com/openprovider/contracts/v2/product/price"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakePriceClient answers every search with one price per requested TLD, in reverse order. Searches for
+// several TLDs also get a price for a domain nobody asked for. Costs are looked up in costs by domain.
+type fakePriceClient struct {
+	pricepb.PriceServiceClient
+
+	costs map[string]int64
+
+	mu       sync.Mutex
+	requests []string
+}
+
+func (f *fakePriceClient) SearchPriceFastCheckout(_ context.Context, in *pricepb.SearchPricesRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[pricepb.SearchPricesResponse], error) {
+	tlds := in.Filter.Product.(*pricepb.PriceFilter_Domain).Domain.TldFilter.(*pricepb.DomainPriceFilter_IncludedTldNames).IncludedTldNames
+
+	f.mu.Lock()
+	f.requests = append(f.requests, in.Query+":"+tlds)
+	f.mu.Unlock()
+
+	stream := &fakePriceStream{}
+	names := strings.Split(tlds, ",")
+	for i := len(names) - 1; i >= 0; i-- {
+		domain := in.Query + "." + names[i]
+		stream.responses = append(stream.responses, priceResponse(domain, f.costs[domain]))
+	}
+	if len(names) > 1 {
+		stream.responses = append(stream.responses, priceResponse("stranger.com", 1))
+	}
+	return stream, nil
+}
+
+type fakePriceStream struct {
+	grpc.ClientStream
+	responses []*pricepb.SearchPricesResponse
+}
+
+func (s *fakePriceStream) Recv() (*pricepb.SearchPricesResponse, error) {
+	if len(s.responses) == 0 {
+		return nil, io.EOF
+	}
+	resp := s.responses[0]
+	s.responses = s.responses[1:]
+	return resp, nil
+}
+
+func priceResponse(domain string, units int64) *pricepb.SearchPricesResponse {
+	return &pricepb.SearchPricesResponse{
+		Response: &pricepb.SearchPricesResponse_Price{Price: &pricepb.PriceData{
+			Domain: domain,
+			Prices: map[string]*pricepb.ProductPrice{
+				registrationPricePriority: {Price: &pricepb.Price{CurrencyCode: "USD", Units: units}},
+			},
+		}},
+	}
+}
+
+func TestStreamPricesBatchGroupsByLabel(t *testing.T) {
+	client := &fakePriceClient{costs: map[string]int64{
+		"brand.com": 12,
+		"brand.io":  35,
+		"brand.ai":  70,
+		"other.net": 9,
+	}}
+	svc := NewPriceService(client, nil, nil)
+
+	got := make(map[string][]string)
+	err := svc.StreamPricesBatch(context.Background(), []string{"brand.com", "brand.io", "brand.ai", "other.net"}, "USD",
+		func(domain string, resp *domainsearchv1.SearchPricesResponse) error {
+			price := resp.GetPrice()
+			if price == nil {
+				t.Errorf("%s: unexpected response %v", domain, resp)
+				return nil
+			}
+			got[domain] = append(got[domain], price.GetDomain()+"="+CostOf(price).String())
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("StreamPricesBatch: %v", err)
+	}
+
+	sort.Strings(client.requests)
+	if want := []string{"brand:com,io,ai", "other:net"}; strings.Join(client.requests, " ") != strings.Join(want, " ") {
+		t.Errorf("upstream requests = %v, want %v", client.requests, want)
+	}
+	want := map[string]string{
+		"brand.com": "brand.com=12",
+		"brand.io":  "brand.io=35",
+		"brand.ai":  "brand.ai=70",
+		"other.net": "other.net=9",
+	}
+	if len(got) != len(want) {
+		t.Errorf("answered domains = %v, want %v", got, want)
+	}
+	for domain, price := range want {
+		if len(got[domain]) != 1 || got[domain][0] != price {
+			t.Errorf("%s: got %v, want [%s]", domain, got[domain], price)
+		}
+	}
+}
+
+// scriptedPriceClient answers every search with the responses script returns for it. Streams block until
+// release is closed, when it is set.
+type scriptedPriceClient struct {
+	pricepb.PriceServiceClient
+
+	script  func(in *pricepb.SearchPricesRequest) []*pricepb.SearchPricesResponse
+	release chan struct{}
+
+	mu       sync.Mutex
+	requests []string
+}
+
+func (c *scriptedPriceClient) SearchPriceFastCheckout(ctx context.Context, in *pricepb.SearchPricesRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[pricepb.SearchPricesResponse], error) {
+	tlds := in.Filter.Product.(*pricepb.PriceFilter_Domain).Domain.TldFilter.(*pricepb.DomainPriceFilter_IncludedTldNames).IncludedTldNames
+	c.mu.Lock()
+	c.requests = append(c.requests, in.Query+":"+tlds)
+	c.mu.Unlock()
+	if c.release != nil {
+		select {
+		case <-c.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return &fakePriceStream{responses: c.script(in)}, nil
+}
+
+func (c *scriptedPriceClient) requestCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.requests)
+}
+
+func errorPriceResponse(code codes.Code, msg string) *pricepb.SearchPricesResponse {
+	return &pricepb.SearchPricesResponse{
+		Response: &pricepb.SearchPricesResponse_Error{Error: status.New(code, msg).Proto()},
+	}
+}
+
+// collectBatch runs a batch and returns the answers of every domain, as "price=<cost>" or "error=<code>".
+func collectBatch(t *testing.T, svc *PriceService, domains ...string) map[string][]string {
+	t.Helper()
+	var mu sync.Mutex
+	got := make(map[string][]string)
+	err := svc.StreamPricesBatch(context.Background(), domains, "USD", func(domain string, resp *domainsearchv1.SearchPricesResponse) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if price := resp.GetPrice(); price != nil {
+			got[domain] = append(got[domain], "price="+CostOf(price).String())
+		} else {
+			got[domain] = append(got[domain], "error="+codes.Code(resp.GetError().GetCode()).String())
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamPricesBatch: %v", err)
+	}
+	return got
+}
+
+func TestStreamPricesBatchSharesConcurrentGroups(t *testing.T) {
+	client := &scriptedPriceClient{
+		release: make(chan struct{}),
+		script: func(in *pricepb.SearchPricesRequest) []*pricepb.SearchPricesResponse {
+			return []*pricepb.SearchPricesResponse{priceResponse("brand.com", 12), priceResponse("brand.io", 35)}
+		},
+	}
+	svc := NewPriceService(client, nil, nil)
+	key := priceGroupKey("brand", []string{"brand.io", "brand.com"}, "USD")
+
+	results := make(chan map[string][]string, 2)
+	go func() { results <- collectBatch(t, svc, "brand.com", "brand.io") }()
+	waitFor(t, func() bool { return client.requestCount() == 1 })
+	go func() { results <- collectBatch(t, svc, "brand.io", "brand.com") }()
+	waitFor(t, func() bool {
+		svc.flights.mu.Lock()
+		defer svc.flights.mu.Unlock()
+		f, ok := svc.flights.flights[key]
+		if !ok {
+			return false
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		return f.callers == 2
+	})
+	close(client.release)
+
+	for range 2 {
+		got := <-results
+		if strings.Join(got["brand.com"], " ") != "price=12" || strings.Join(got["brand.io"], " ") != "price=35" {
+			t.Errorf("answers = %v, want one price per domain", got)
+		}
+	}
+	if n := client.requestCount(); n != 1 {
+		t.Errorf("upstream requests = %d, want 1", n)
+	}
+}
+
+func TestStreamPricesBatchErrorSkipsPricedDomains(t *testing.T) {
+	client := &scriptedPriceClient{script: func(in *pricepb.SearchPricesRequest) []*pricepb.SearchPricesResponse {
+		return []*pricepb.SearchPricesResponse{priceResponse("brand.com", 12), errorPriceResponse(codes.ResourceExhausted, "quota")}
+	}}
+	cache := NewPriceCache(nil, PriceCacheConfig{})
+	svc := NewPriceService(client, nil, cache)
+
+	got := collectBatch(t, svc, "brand.com", "brand.io")
+	if strings.Join(got["brand.com"], " ") != "price=12" {
+		t.Errorf("brand.com answers = %v, want only its price", got["brand.com"])
+	}
+	if strings.Join(got["brand.io"], " ") != "error=ResourceExhausted" {
+		t.Errorf("brand.io answers = %v, want the upstream error", got["brand.io"])
+	}
+	if cached, ok := cache.Get(context.Background(), "brand.io", "USD"); !ok || cached.GetError() == nil {
+		t.Errorf("cached brand.io = %v, %v; want the error answer", cached, ok)
+	}
+
+	got = collectBatch(t, svc, "brand.com", "brand.io")
+	if n := client.requestCount(); n != 1 {
+		t.Errorf("upstream requests = %d, want 1 after the answers were cached", n)
+	}
+	if strings.Join(got["brand.io"], " ") != "error=ResourceExhausted" {
+		t.Errorf("cached brand.io answers = %v, want the upstream error", got["brand.io"])
+	}
+}
+
+func TestStreamPricesBatchFallsBackForUnnamedPrices(t *testing.T) {
+	client := &scriptedPriceClient{script: func(in *pricepb.SearchPricesRequest) []*pricepb.SearchPricesResponse {
+		tlds := in.Filter.Product.(*pricepb.PriceFilter_Domain).Domain.TldFilter.(*pricepb.DomainPriceFilter_IncludedTldNames).IncludedTldNames
+		if strings.Contains(tlds, ",") {
+			return []*pricepb.SearchPricesResponse{priceResponse("brand.com", 12), priceResponse("", 99)}
+		}
+		return []*pricepb.SearchPricesResponse{priceResponse("", 35)}
+	}}
+	svc := NewPriceService(client, nil, nil)
+
+	got := collectBatch(t, svc, "brand.com", "brand.io")
+	if strings.Join(got["brand.com"], " ") != "price=12" || strings.Join(got["brand.io"], " ") != "price=35" {
+		t.Errorf("answers = %v, want brand.com=12 from the batch and brand.io=35 from its own lookup", got)
+	}
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if want := "brand:com,io brand:io"; strings.Join(client.requests, " ") != want {
+		t.Errorf("upstream requests = %v, want %s", client.requests, want)
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var boltCacheBucket = []byte("cache")
+
+// BoltCache is a Cache persisted in an embedded bbolt file, so a restarted server starts warm. Values are
+// stored behind their expiry time; expired keys are dropped when read and when the file is opened.
+type BoltCache struct {
+	db *bolt.DB
+}
+
+// NewBoltCache opens or creates the cache file at path.
+func NewBoltCache(path string) (*BoltCache, error) {
+	if path == "" {
+		return nil, fmt.Errorf("bolt cache path is required")
+	}
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open bolt cache: %w", err)
+	}
+	c := &BoltCache{db: db}
+	if err := c.purgeExpired(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Get implements Cache.
+func (c *BoltCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	var (
+		value   []byte
+		expired bool
+	)
+	err := c.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltCacheBucket)
+		if bucket == nil {
+			return nil
+		}
+		raw := bucket.Get([]byte(key))
+		if raw == nil {
+			return nil
+		}
+		var expiresAt time.Time
+		expiresAt, value = decodeBoltValue(raw)
+		expired = time.Now().After(expiresAt)
+		return nil
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("bolt cache get: %w", err)
+	}
+	if expired {
+		err := c.db.Update(func(tx *bolt.Tx) error {
+			return tx.Bucket(boltCacheBucket).Delete([]byte(key))
+		})
+		if err != nil {
+			return nil, false, fmt.Errorf("bolt cache delete: %w", err)
+		}
+		return nil, false, nil
+	}
+	return value, value != nil, nil
+}
+
+// Set implements Cache.
+func (c *BoltCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(boltCacheBucket)
+		if err != nil {
+			return err
+		}
+		return bucket.Put([]byte(key), encodeBoltValue(time.Now().Add(ttl), value))
+	})
+	if err != nil {
+		return fmt.Errorf("bolt cache set: %w", err)
+	}
+	return nil
+}
+
+// DeletePrefix implements Cache.
+func (c *BoltCache) DeletePrefix(_ context.Context, prefix string) (int, error) {
+	removed := 0
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltCacheBucket)
+		if bucket == nil {
+			return nil
+		}
+		cursor := bucket.Cursor()
+		for k, _ := cursor.Seek([]byte(prefix)); k != nil && bytes.HasPrefix(k, []byte(prefix)); k, _ = cursor.Next() {
+			if err := cursor.Delete(); err != nil {
+				return err
+			}
+			removed++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("bolt cache delete: %w", err)
+	}
+	return removed, nil
+}
+
+// Close implements Cache.
+func (c *BoltCache) Close() error {
+	return c.db.Close()
+}
+
+func (c *BoltCache) purgeExpired() error {
+	now := time.Now()
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(boltCacheBucket)
+		if err != nil {
+			return err
+		}
+		cursor := bucket.Cursor()
+		for k, v := cursor.First(); k != nil; k, v = cursor.Next() {
+			if expiresAt, _ := decodeBoltValue(v); now.After(expiresAt) {
+				if err := cursor.Delete(); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("bolt cache purge: %w", err)
+	}
+	return nil
+}
+
+func encodeBoltValue(expiresAt time.Time, value []byte) []byte {
+	out := make([]byte, 8+len(value))
+	binary.BigEndian.PutUint64(out, uint64(expiresAt.UnixNano()))
+	copy(out[8:], value)
+	return out
+}
+
+// decodeBoltValue splits a stored value; the returned slice is copied because bbolt memory is only valid
+// inside the transaction.
+func decodeBoltValue(raw []byte) (time.Time, []byte) {
+	if len(raw) < 8 {
+		return time.Time{}, nil
+	}
+	expiresAt := time.Unix(0, int64(binary.BigEndian.Uint64(raw)))
+	return expiresAt, append([]byte(nil), raw[8:]...)
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBoltCache(t *testing.T) {
+	c, err := NewBoltCache(filepath.Join(t.TempDir(), "cache.db"))
+	if err != nil {
+		t.Fatalf("NewBoltCache: %v", err)
+	}
+	defer c.Close()
+	testCacheBackend(t, c, time.Sleep)
+}
+
+func TestBoltCachePersistsAndPurges(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cache.db")
+	c, err := NewBoltCache(path)
+	if err != nil {
+		t.Fatalf("NewBoltCache: %v", err)
+	}
+	if err := c.Set(ctx, "price|com|kept.com|USD", []byte("kept"), time.Hour); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := c.Set(ctx, "price|com|gone.com|USD", []byte("gone"), time.Millisecond); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	c, err = NewBoltCache(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer c.Close()
+	if value, ok, err := c.Get(ctx, "price|com|kept.com|USD"); !ok || err != nil || string(value) != "kept" {
+		t.Fatalf("kept key after reopen = %q, %v, %v", value, ok, err)
+	}
+	if removed, err := c.DeletePrefix(ctx, "price|com|gone.com|"); err != nil || removed != 0 {
+		t.Fatalf("expired key survived the reopen: removed=%d err=%v", removed, err)
+	}
+}
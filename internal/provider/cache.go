@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/olaysco/domain-search-llm/internal/domainname"
+)
+
+// Cache is the key/value store behind the price and availability caches. Values are opaque bytes and expire
+// after the TTL given to Set. Implementations are safe for concurrent use and may be shared between replicas.
+type Cache interface {
+	// Get returns the value stored under key, reporting false when it is missing or expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value under key for ttl.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// DeletePrefix removes every key starting with prefix and returns how many were removed.
+	DeletePrefix(ctx context.Context, prefix string) (int, error)
+	// Close releases the resources held by the cache.
+	Close() error
+}
+
+// NewCache opens the cache described by spec:
+//
+//	memory                      in-process LRU cache holding at most maxEntries keys (the default)
+//	bolt:/var/lib/cache.db      embedded bbolt file, kept across restarts
+//	redis://host:6379/0         Redis server (or anything speaking its protocol), shared between replicas
+func NewCache(spec string, maxEntries int) (Cache, error) {
+	spec = strings.TrimSpace(spec)
+	switch {
+	case spec == "" || spec == "memory":
+		return NewMemoryCache(maxEntries), nil
+	case strings.HasPrefix(spec, "bolt:"):
+		return NewBoltCache(strings.TrimPrefix(spec, "bolt:"))
+	case strings.HasPrefix(spec, "redis://"), strings.HasPrefix(spec, "rediss://"):
+		return NewRedisCache(spec)
+	default:
+		return nil, fmt.Errorf("unsupported cache %q, expected memory, bolt:<path> or redis://<addr>", spec)
+	}
+}
+
+const defaultMemoryCacheEntries = 10000
+
+// MemoryCacheStats is a snapshot of the MemoryCache counters.
+type MemoryCacheStats struct {
+	Entries     int    `json:"entries"`
+	Evictions   uint64 `json:"evictions"`
+	Expirations uint64 `json:"expirations"`
+}
+
+// MemoryCache is a size-bounded in-process LRU Cache. When it is full the least recently used key is evicted.
+type MemoryCache struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	stats   MemoryCacheStats
+}
+
+type memoryCacheEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewMemoryCache builds an empty cache holding at most maxEntries keys.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	if maxEntries <= 0 {
+		maxEntries = defaultMemoryCacheEntries
+	}
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// Get implements Cache.
+func (c *MemoryCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := elem.Value.(*memoryCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.remove(elem)
+		c.stats.Expirations++
+		return nil, false, nil
+	}
+	c.lru.MoveToFront(elem)
+	return entry.value, true, nil
+}
+
+// Set implements Cache.
+func (c *MemoryCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	expiresAt := time.Now().Add(ttl)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*memoryCacheEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.lru.MoveToFront(elem)
+		return nil
+	}
+	c.entries[key] = c.lru.PushFront(&memoryCacheEntry{key: key, value: value, expiresAt: expiresAt})
+	for c.lru.Len() > c.maxEntries {
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
+	return nil
+}
+
+// DeletePrefix implements Cache.
+func (c *MemoryCache) DeletePrefix(_ context.Context, prefix string) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	removed := 0
+	for elem := c.lru.Front(); elem != nil; {
+		next := elem.Next()
+		if strings.HasPrefix(elem.Value.(*memoryCacheEntry).key, prefix) {
+			c.remove(elem)
+			removed++
+		}
+		elem = next
+	}
+	return removed, nil
+}
+
+// Close implements Cache.
+func (c *MemoryCache) Close() error {
+	return nil
+}
+
+// Stats returns the current counters.
+func (c *MemoryCache) Stats() MemoryCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Entries = c.lru.Len()
+	return stats
+}
+
+func (c *MemoryCache) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*memoryCacheEntry)
+	delete(c.entries, entry.key)
+}
+
+// CacheLookupStats counts the lookups made by a typed cache on top of a Cache.
+type CacheLookupStats struct {
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+	Errors uint64 `json:"errors"`
+}
+
+// domainCacheKey lays keys out as kind|suffix|domain|variant, with the labels of the public suffix reversed
+// ("uk.co" for co.uk), so that a domain, a public suffix or a TLD together with every suffix under it can be
+// invalidated with prefix deletions.
+func domainCacheKey(kind, domain, variant string) string {
+	domain = normalizeCacheDomain(domain)
+	_, suffix := extractTLD(domain)
+	return kind + "|" + reverseLabels(suffix) + "|" + domain + "|" + variant
+}
+
+// deleteSuffix removes the keys of every domain under a public suffix, given with or without the leading
+// dot, and returns how many were removed. Suffixes below it are included, so "uk" also covers co.uk.
+func deleteSuffix(ctx context.Context, backend Cache, kind, suffix string) (int, error) {
+	prefix := kind + "|" + reverseLabels(normalizeCacheDomain(strings.TrimPrefix(strings.TrimSpace(suffix), ".")))
+	removed, err := backend.DeletePrefix(ctx, prefix+"|")
+	if err != nil {
+		return removed, err
+	}
+	below, err := backend.DeletePrefix(ctx, prefix+".")
+	return removed + below, err
+}
+
+func reverseLabels(name string) string {
+	labels := strings.Split(name, ".")
+	slices.Reverse(labels)
+	return strings.Join(labels, ".")
+}
+
+// normalizeCacheDomain folds Unicode and A-label spellings of a name onto the same key.
+func normalizeCacheDomain(domain string) string {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if ascii, err := domainname.ToASCII(domain); err == nil {
+		return ascii
+	}
+	return domain
+}
@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
+)
+
+// testCacheBackend runs the behaviour every Cache implementation shares. advance moves the backend's clock
+// forward by at least the given duration.
+func testCacheBackend(t *testing.T, c Cache, advance func(time.Duration)) {
+	ctx := context.Background()
+
+	t.Run("get and set", func(t *testing.T) {
+		if _, ok, err := c.Get(ctx, "price|com|missing.com|USD"); ok || err != nil {
+			t.Fatalf("missing key: ok=%v err=%v", ok, err)
+		}
+		if err := c.Set(ctx, "price|com|brand.com|USD", []byte("12.00"), time.Hour); err != nil {
+			t.Fatalf("Set: %v", err)
+		}
+		value, ok, err := c.Get(ctx, "price|com|brand.com|USD")
+		if err != nil || !ok || string(value) != "12.00" {
+			t.Fatalf("Get = %q, %v, %v; want 12.00", value, ok, err)
+		}
+	})
+
+	t.Run("ttl", func(t *testing.T) {
+		if err := c.Set(ctx, "price|com|short.com|USD", []byte("1"), 50*time.Millisecond); err != nil {
+			t.Fatalf("Set: %v", err)
+		}
+		if err := c.Set(ctx, "price|com|long.com|USD", []byte("2"), time.Hour); err != nil {
+			t.Fatalf("Set: %v", err)
+		}
+		advance(100 * time.Millisecond)
+		if _, ok, err := c.Get(ctx, "price|com|short.com|USD"); ok || err != nil {
+			t.Fatalf("expired key: ok=%v err=%v", ok, err)
+		}
+		if _, ok, err := c.Get(ctx, "price|com|long.com|USD"); !ok || err != nil {
+			t.Fatalf("fresh key: ok=%v err=%v", ok, err)
+		}
+	})
+
+	t.Run("delete prefix", func(t *testing.T) {
+		keys := []string{
+			"price|io|a.io|USD",
+			"price|io|a.io|EUR",
+			"price|io|a.io|EUR|table",
+			"price|io|ab.io|USD",
+			"availability|io|a.io|",
+			"price|io|x*y.io|USD",
+			"price|io|xzzy.io|USD",
+		}
+		for _, key := range keys {
+			if err := c.Set(ctx, key, []byte("v"), time.Hour); err != nil {
+				t.Fatalf("Set %s: %v", key, err)
+			}
+		}
+
+		removed, err := c.DeletePrefix(ctx, "price|io|a.io|")
+		if err != nil || removed != 3 {
+			t.Fatalf("DeletePrefix = %d, %v; want 3", removed, err)
+		}
+		removed, err = c.DeletePrefix(ctx, "price|io|x*")
+		if err != nil || removed != 1 {
+			t.Fatalf("DeletePrefix with a glob character = %d, %v; want 1", removed, err)
+		}
+		for _, key := range []string{"price|io|ab.io|USD", "availability|io|a.io|", "price|io|xzzy.io|USD"} {
+			if _, ok, err := c.Get(ctx, key); !ok || err != nil {
+				t.Errorf("%s was removed: ok=%v err=%v", key, ok, err)
+			}
+		}
+		if _, ok, _ := c.Get(ctx, "price|io|a.io|EUR"); ok {
+			t.Error("price|io|a.io|EUR survived DeletePrefix")
+		}
+	})
+
+	t.Run("price cache stats", func(t *testing.T) {
+		prices := NewPriceCache(c, PriceCacheConfig{Namespace: "stats"})
+		resp := &domainsearchv1.SearchPricesResponse{Response: &domainsearchv1.SearchPricesResponse_Price{
+			Price: &domainsearchv1.Price{Domain: "stats.dev"},
+		}}
+		if _, ok := prices.Get(ctx, "stats.dev", "USD"); ok {
+			t.Fatal("unexpected hit")
+		}
+		prices.Set(ctx, "stats.dev", "usd", resp)
+		got, ok := prices.Get(ctx, "stats.dev", "USD")
+		if !ok || got.GetPrice().GetDomain() != "stats.dev" {
+			t.Fatalf("Get = %v, %v; want the stored price", got, ok)
+		}
+		if stats := prices.Stats(); stats != (CacheLookupStats{Hits: 1, Misses: 1}) {
+			t.Fatalf("Stats = %+v, want 1 hit and 1 miss", stats)
+		}
+	})
+}
+
+func TestMemoryCache(t *testing.T) {
+	testCacheBackend(t, NewMemoryCache(0), time.Sleep)
+}
+
+func TestPriceCacheInvalidateTLDCoversSuffixesBelow(t *testing.T) {
+	ctx := context.Background()
+	prices := NewPriceCache(NewMemoryCache(0), PriceCacheConfig{})
+	domains := []string{"example.uk", "example.co.uk", "example.org.uk", "example.com", "example.k"}
+	store := func() {
+		for _, domain := range domains {
+			prices.Set(ctx, domain, "USD", &domainsearchv1.SearchPricesResponse{Response: &domainsearchv1.SearchPricesResponse_Price{
+				Price: &domainsearchv1.Price{Domain: domain},
+			}})
+		}
+	}
+	cached := func() map[string]bool {
+		out := make(map[string]bool)
+		for _, domain := range domains {
+			if _, ok := prices.Get(ctx, domain, "USD"); ok {
+				out[domain] = true
+			}
+		}
+		return out
+	}
+
+	store()
+	if removed, err := prices.InvalidateTLD(ctx, "co.uk"); err != nil || removed != 1 {
+		t.Fatalf("InvalidateTLD(co.uk) = %d, %v; want 1", removed, err)
+	}
+	if got := cached(); got["example.co.uk"] || !got["example.org.uk"] || !got["example.uk"] {
+		t.Errorf("after invalidating co.uk: %v", got)
+	}
+
+	store()
+	if removed, err := prices.InvalidateTLD(ctx, ".UK"); err != nil || removed != 3 {
+		t.Fatalf("InvalidateTLD(.UK) = %d, %v; want 3", removed, err)
+	}
+	if got := cached(); len(got) != 2 || !got["example.com"] || !got["example.k"] {
+		t.Errorf("after invalidating uk: %v", got)
+	}
+}
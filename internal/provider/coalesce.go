@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"sync"
+
+	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// priceFlight is one upstream price stream shared by every caller asking for the same domain and currency
+// while it is running. Responses are buffered so that late joiners replay what they missed.
+type priceFlight struct {
+	cancel context.CancelFunc
+
+	mu        sync.Mutex
+	responses []*domainsearchv1.SearchPricesResponse
+	updated   chan struct{}
+	done      bool
+	err       error
+	callers   int
+}
+
+// flightGroup deduplicates concurrent upstream streams by key.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*priceFlight
+}
+
+// join attaches the caller to the running flight for key, or starts one with fetch. The flight runs on a
+// context detached from the caller and is cancelled once every caller has left before it finished.
+func (g *flightGroup) join(ctx context.Context, key string, fetch func(ctx context.Context, f *priceFlight) error) *priceFlight {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if f, ok := g.flights[key]; ok {
+		f.mu.Lock()
+		f.callers++
+		f.mu.Unlock()
+		return f
+	}
+	if g.flights == nil {
+		g.flights = make(map[string]*priceFlight)
+	}
+
+	flightCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	f := &priceFlight{cancel: cancel, updated: make(chan struct{}), callers: 1}
+	g.flights[key] = f
+	go func() {
+		err := fetch(flightCtx, f)
+		g.forget(key, f)
+		f.finish(err)
+		cancel()
+	}()
+	return f
+}
+
+// leave detaches a caller, cancelling the flight when nobody is left waiting for it.
+func (g *flightGroup) leave(key string, f *priceFlight) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.callers--
+	if f.callers == 0 && !f.done {
+		if g.flights[key] == f {
+			delete(g.flights, key)
+		}
+		f.cancel()
+	}
+}
+
+// running reports whether a flight for key is in progress.
+func (g *flightGroup) running(key string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	_, ok := g.flights[key]
+	return ok
+}
+
+func (g *flightGroup) forget(key string, f *priceFlight) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.flights[key] == f {
+		delete(g.flights, key)
+	}
+}
+
+// publish appends a response and wakes the callers waiting for it.
+func (f *priceFlight) publish(resp *domainsearchv1.SearchPricesResponse) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.responses = append(f.responses, resp)
+	close(f.updated)
+	f.updated = make(chan struct{})
+}
+
+func (f *priceFlight) finish(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.done = true
+	f.err = err
+	close(f.updated)
+}
+
+// relay hands every response of the flight to the handler, in order, until the flight ends, the handler
+// fails or ctx is done. Each caller receives its own copy of the responses.
+func (f *priceFlight) relay(ctx context.Context, handler PriceStreamHandler) error {
+	for next := 0; ; {
+		f.mu.Lock()
+		if next < len(f.responses) {
+			resp := proto.Clone(f.responses[next]).(*domainsearchv1.SearchPricesResponse)
+			f.mu.Unlock()
+			next++
+			if err := handler(resp); err != nil {
+				return err
+			}
+			continue
+		}
+		if f.done {
+			err := f.err
+			f.mu.Unlock()
+			return err
+		}
+		updated := f.updated
+		f.mu.Unlock()
+
+		select {
+		case <-updated:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
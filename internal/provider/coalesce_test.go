@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
+)
+
+func domainResponse(domain string) *domainsearchv1.SearchPricesResponse {
+	return &domainsearchv1.SearchPricesResponse{
+		Response: &domainsearchv1.SearchPricesResponse_Price{Price: &domainsearchv1.Price{Domain: domain}},
+	}
+}
+
+func relayDomains(ctx context.Context, f *priceFlight) ([]string, error) {
+	var domains []string
+	err := f.relay(ctx, func(resp *domainsearchv1.SearchPricesResponse) error {
+		domains = append(domains, resp.GetPrice().GetDomain())
+		return nil
+	})
+	return domains, err
+}
+
+func TestFlightGroupReplaysToLateJoiners(t *testing.T) {
+	var g flightGroup
+	published := make(chan struct{})
+	release := make(chan struct{})
+	fetches := 0
+	fetch := func(_ context.Context, f *priceFlight) error {
+		fetches++
+		f.publish(domainResponse("brand.com"))
+		close(published)
+		<-release
+		f.publish(domainResponse("brand.io"))
+		return nil
+	}
+
+	leader := g.join(context.Background(), "brand", fetch)
+	defer g.leave("brand", leader)
+	<-published
+	follower := g.join(context.Background(), "brand", fetch)
+	defer g.leave("brand", follower)
+	if follower != leader {
+		t.Fatal("late joiner started a second flight")
+	}
+	close(release)
+
+	for name, f := range map[string]*priceFlight{"leader": leader, "follower": follower} {
+		got, err := relayDomains(context.Background(), f)
+		if err != nil {
+			t.Fatalf("%s relay: %v", name, err)
+		}
+		if len(got) != 2 || got[0] != "brand.com" || got[1] != "brand.io" {
+			t.Errorf("%s received %v, want [brand.com brand.io]", name, got)
+		}
+	}
+	if fetches != 1 {
+		t.Errorf("fetches = %d, want 1", fetches)
+	}
+}
+
+func TestFlightGroupSurvivesLeaderCancellation(t *testing.T) {
+	var g flightGroup
+	release := make(chan struct{})
+	fetchErr := make(chan error, 1)
+	fetch := func(ctx context.Context, f *priceFlight) error {
+		select {
+		case <-release:
+		case <-ctx.Done():
+			fetchErr <- ctx.Err()
+			return ctx.Err()
+		}
+		f.publish(domainResponse("brand.com"))
+		fetchErr <- nil
+		return nil
+	}
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leader := g.join(leaderCtx, "brand", fetch)
+	follower := g.join(context.Background(), "brand", fetch)
+	defer g.leave("brand", follower)
+
+	cancelLeader()
+	if _, err := relayDomains(leaderCtx, leader); err != context.Canceled {
+		t.Fatalf("leader relay error = %v, want context.Canceled", err)
+	}
+	g.leave("brand", leader)
+	close(release)
+
+	got, err := relayDomains(context.Background(), follower)
+	if err != nil {
+		t.Fatalf("follower relay: %v", err)
+	}
+	if len(got) != 1 || got[0] != "brand.com" {
+		t.Errorf("follower received %v, want [brand.com]", got)
+	}
+	if err := <-fetchErr; err != nil {
+		t.Errorf("fetch was cancelled with the leader: %v", err)
+	}
+}
+
+func TestFlightGroupCancelsAbandonedFlight(t *testing.T) {
+	var g flightGroup
+	cancelled := make(chan struct{})
+	f := g.join(context.Background(), "brand", func(ctx context.Context, _ *priceFlight) error {
+		<-ctx.Done()
+		close(cancelled)
+		return ctx.Err()
+	})
+	g.leave("brand", f)
+	<-cancelled
+	if g.running("brand") {
+		t.Error("abandoned flight is still registered")
+	}
+}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
+	"github.com/olaysco/domain-search-llm/internal/periodic"
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ErrNoRate is returned when the exchange rates do not list one of the currencies of a conversion.
+var ErrNoRate = errors.New("no exchange rate")
+
+// RateConverter implements CurrencyConverter with exchange rates loaded from a RateSource.
+type RateConverter struct {
+	source RateSource
+
+	mu    sync.RWMutex
+	rates *ExchangeRates
+}
+
+// NewRateConverter builds a RateConverter and loads the initial rates.
+func NewRateConverter(ctx context.Context, source RateSource) (*RateConverter, error) {
+	c := &RateConverter{source: source}
+	if err := c.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Refresh reloads the rates. The previous rates stay active when loading fails.
+func (c *RateConverter) Refresh(ctx context.Context) error {
+	rates, err := c.source.LoadRates(ctx)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.rates = rates
+	c.mu.Unlock()
+	return nil
+}
+
+// Run refreshes the rates every interval until ctx is cancelled. Refresh failures are reported to onError,
+// which may be nil.
+func (c *RateConverter) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	periodic.Run(ctx, interval, c.Refresh, onError)
+}
+
+// Rate returns how many units of to one unit of from is worth, together with the time the rates were published.
+func (c *RateConverter) Rate(from, to string) (decimal.Decimal, time.Time, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+
+	c.mu.RLock()
+	rates := c.rates
+	c.mu.RUnlock()
+
+	fromRate, ok := rates.Rates[from]
+	if !ok {
+		return decimal.Zero, time.Time{}, fmt.Errorf("%w for %s", ErrNoRate, from)
+	}
+	toRate, ok := rates.Rates[to]
+	if !ok {
+		return decimal.Zero, time.Time{}, fmt.Errorf("%w for %s", ErrNoRate, to)
+	}
+	return toRate.Div(fromRate), rates.UpdatedAt, nil
+}
+
+// Convert converts an amount between currencies, rounded to the minor units of the target currency.
+func (c *RateConverter) Convert(amount decimal.Decimal, from, to string) (decimal.Decimal, error) {
+	rate, _, err := c.Rate(from, to)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return amount.Mul(rate).Round(minorUnits(strings.ToUpper(to))), nil
+}
+
+// ConvertPrice implements CurrencyConverter, re-quoting the registration, renewal and transfer costs and
+// recording the rate used.
+func (c *RateConverter) ConvertPrice(_ context.Context, price *domainsearchv1.Price, currency string) error {
+	from, to := strings.ToUpper(price.GetCurrency()), strings.ToUpper(currency)
+	rate, updatedAt, err := c.Rate(from, to)
+	if err != nil {
+		return err
+	}
+
+	units := minorUnits(to)
+	cost := CostOf(price).Mul(rate).Round(units)
+	renewal := RenewalCostOf(price).Mul(rate).Round(units)
+
+	SetAmounts(price, to, cost, renewal)
+	if transfer := price.GetAmounts().GetTransferCost(); transfer != nil {
+		price.Amounts.TransferCost = NewMoney(MoneyAmount(transfer).Mul(rate).Round(units), to)
+	}
+	price.Conversion = &domainsearchv1.CurrencyConversion{
+		FromCurrency: from,
+		Rate:         rate.Round(8).String(),
+	}
+	if !updatedAt.IsZero() {
+		price.Conversion.RateTime = timestamppb.New(updatedAt)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
+	"github.com/shopspring/decimal"
+)
+
+func testConverter() *RateConverter {
+	return &RateConverter{rates: &ExchangeRates{
+		Rates: map[string]decimal.Decimal{
+			"EUR": decimal.NewFromInt(1),
+			"USD": decimal.RequireFromString("1.10"),
+		},
+		UpdatedAt: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+	}}
+}
+
+func testPrice(currency, cost, renewal string) *domainsearchv1.Price {
+	price := &domainsearchv1.Price{Domain: "brand.com"}
+	SetAmounts(price, currency, decimal.RequireFromString(cost), decimal.RequireFromString(renewal))
+	return price
+}
+
+func TestConvertPriceWithRate(t *testing.T) {
+	price := testPrice("EUR", "10.00", "20.00")
+	price.Amounts.TransferCost = NewMoney(decimal.RequireFromString("5.00"), "EUR")
+	if err := convertPrice(context.Background(), testConverter(), price, "USD"); err != nil {
+		t.Fatal(err)
+	}
+	if price.GetCurrency() != "USD" || !CostOf(price).Equal(decimal.RequireFromString("11")) || !RenewalCostOf(price).Equal(decimal.RequireFromString("22")) {
+		t.Fatalf("converted price = %s %s / %s", price.GetCurrency(), CostOf(price), RenewalCostOf(price))
+	}
+	if transfer := price.GetAmounts().GetTransferCost(); transfer.GetCurrencyCode() != "USD" || !MoneyAmount(transfer).Equal(decimal.RequireFromString("5.5")) {
+		t.Fatalf("converted transfer cost = %v, want 5.5 USD", transfer)
+	}
+	if price.GetConversion().GetFromCurrency() != "EUR" {
+		t.Fatalf("conversion = %v, want from EUR", price.GetConversion())
+	}
+}
+
+func TestConvertPriceWithoutRateKeepsQuotedCurrency(t *testing.T) {
+	price := testPrice("GBP", "8.50", "9.50")
+	if err := convertPrice(context.Background(), testConverter(), price, "USD"); err != nil {
+		t.Fatalf("missing rate should not fail the price: %v", err)
+	}
+	if price.GetCurrency() != "GBP" || !CostOf(price).Equal(decimal.RequireFromString("8.50")) || !RenewalCostOf(price).Equal(decimal.RequireFromString("9.50")) {
+		t.Fatalf("price changed to %s %s / %s", price.GetCurrency(), CostOf(price), RenewalCostOf(price))
+	}
+	if price.GetConversion() != nil {
+		t.Fatalf("conversion = %v, want none", price.GetConversion())
+	}
+}
+
+type brokenConverter struct{}
+
+func (brokenConverter) ConvertPrice(context.Context, *domainsearchv1.Price, string) error {
+	return errors.New("rates store unreachable")
+}
+
+func TestConvertPriceReportsConverterFaults(t *testing.T) {
+	price := testPrice("EUR", "10.00", "20.00")
+	if err := convertPrice(context.Background(), brokenConverter{}, price, "USD"); err == nil {
+		t.Fatal("expected the converter fault to be returned")
+	}
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"strings"
+
+	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
+	pricepb "github.com/openprovider/contracts/v2/product/price"
+	"github.com/shopspring/decimal"
+)
+
+var nanosPerUnit = decimal.New(1, 9)
+
+// NewMoney converts a decimal amount into its units/nanos representation. Digits beyond nano precision are
+// truncated.
+func NewMoney(amount decimal.Decimal, currency string) *domainsearchv1.Money {
+	units := amount.Truncate(0)
+	nanos := amount.Sub(units).Mul(nanosPerUnit).Truncate(0)
+	return &domainsearchv1.Money{
+		CurrencyCode: strings.ToUpper(currency),
+		Units:        units.IntPart(),
+		Nanos:        int32(nanos.IntPart()),
+	}
+}
+
+// MoneyAmount converts a Money message back into an exact decimal amount.
+func MoneyAmount(m *domainsearchv1.Money) decimal.Decimal {
+	if m == nil {
+		return decimal.Zero
+	}
+	return decimal.NewFromInt(m.GetUnits()).Add(decimal.New(int64(m.GetNanos()), -9))
+}
+
+// CostOf returns the exact registration cost of a price, falling back to the deprecated float field for
+// prices produced before the exact amounts existed.
+func CostOf(price *domainsearchv1.Price) decimal.Decimal {
+	if amount := price.GetAmounts().GetCost(); amount != nil {
+		return MoneyAmount(amount)
+	}
+	return decimal.NewFromFloat32(price.GetCost())
+}
+
+// RenewalCostOf returns the exact renewal cost of a price, with the same fallback as CostOf.
+func RenewalCostOf(price *domainsearchv1.Price) decimal.Decimal {
+	if amount := price.GetAmounts().GetRenewalCost(); amount != nil {
+		return MoneyAmount(amount)
+	}
+	return decimal.NewFromFloat32(price.GetRenewalCost())
+}
+
+// SetAmounts writes the currency, cost and renewal cost of a price in both the exact amounts and the
+// deprecated float fields kept for older clients. Other exact amounts of the price are left as they are.
+func SetAmounts(price *domainsearchv1.Price, currency string, cost, renewal decimal.Decimal) {
+	currency = strings.ToUpper(currency)
+	costValue, _ := cost.Float64()
+	renewalValue, _ := renewal.Float64()
+
+	price.Currency = currency
+	if price.Amounts == nil {
+		price.Amounts = &domainsearchv1.PriceAmounts{}
+	}
+	price.Amounts.Cost = NewMoney(cost, currency)
+	price.Amounts.RenewalCost = NewMoney(renewal, currency)
+	price.Cost = float32(costValue)
+	price.RenewalCost = float32(renewalValue)
+}
+
+// upstreamAmount reads the exact amount of an upstream price, preferring its decimal string value.
+func upstreamAmount(price *pricepb.Price) decimal.Decimal {
+	if price == nil {
+		return decimal.Zero
+	}
+	if val := price.GetValue(); val != "" {
+		if parsed, err := decimal.NewFromString(val); err == nil {
+			return parsed
+		}
+	}
+	return decimal.NewFromInt(price.GetUnits()).Add(decimal.New(int64(price.GetNanos()), -9))
+}
@@ -2,62 +2,84 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
-	"strconv"
 	"strings"
-	"sync"
 
+	"github.com/olaysco/domain-search-llm/internal/domainname"
 	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
 	pricepb "github.com/openprovider/contracts/v2/product/price"
 	"golang.org/x/net/publicsuffix"
+	"google.golang.org/grpc/status"
 )
 
 // PriceStreamHandler is invoked for each SearchPricesResponse returned by the upstream service.
 type PriceStreamHandler func(*domainsearchv1.SearchPricesResponse) error
 
+// DefaultCurrency is used when the caller does not request a currency.
+const DefaultCurrency = "USD"
+
 // PriceProvider exposes a uniform interface for streaming product prices from different vendors.
 type PriceProvider interface {
-	StreamPrices(ctx context.Context, req string, handler PriceStreamHandler) error
+	StreamPrices(ctx context.Context, req string, currency string, handler PriceStreamHandler) error
+}
+
+// CurrencyConverter re-quotes a price in another ISO 4217 currency.
+type CurrencyConverter interface {
+	ConvertPrice(ctx context.Context, price *domainsearchv1.Price, currency string) error
 }
 
 // PriceService implements PriceProvider on top of the Openprovider PriceService gRPC API.
 type PriceService struct {
-	client pricepb.PriceServiceClient
-	cache  map[string]*domainsearchv1.Price
-	mu     sync.RWMutex
+	client    pricepb.PriceServiceClient
+	converter CurrencyConverter
+	cache     *PriceCache
+	flights   flightGroup
 }
 
-// NewPriceService wires the external PriceService client into our provider abstraction.
-func NewPriceService(client pricepb.PriceServiceClient) *PriceService {
+// NewPriceService wires the external PriceService client into our provider abstraction. The converter is
+// used when the upstream cannot quote in the requested currency; nil leaves such prices in the upstream currency.
+// A nil cache selects an in-memory cache with the default limits.
+func NewPriceService(client pricepb.PriceServiceClient, converter CurrencyConverter, cache *PriceCache) *PriceService {
+	if cache == nil {
+		cache = NewPriceCache(nil, PriceCacheConfig{})
+	}
 	return &PriceService{
-		client: client,
-		cache:  make(map[string]*domainsearchv1.Price),
+		client:    client,
+		converter: converter,
+		cache:     cache,
 	}
 }
 
 // StreamPrices forwards the request to the upstream gRPC service and relays every streamed response
 // to the provided handler. The handler is invoked synchronously for each incoming message.
-// Results are cached for subsequent calls with the same domain.
-func (p *PriceService) StreamPrices(ctx context.Context, req string, handler PriceStreamHandler) error {
+// Prices and upstream errors are cached for subsequent calls with the same domain and currency, and
+// concurrent calls for the same domain and currency share a single upstream stream.
+func (p *PriceService) StreamPrices(ctx context.Context, req string, currency string, handler PriceStreamHandler) error {
 	if handler == nil {
 		return fmt.Errorf("price stream handler cannot be nil")
 	}
 
-	cacheKey := strings.ToLower(strings.TrimSpace(req))
-
-	p.mu.RLock()
-	if cachedPrice, ok := p.cache[cacheKey]; ok {
-		p.mu.RUnlock()
-		return handler(&domainsearchv1.SearchPricesResponse{
-			Response: &domainsearchv1.SearchPricesResponse_Price{Price: cachedPrice},
-		})
+	currency = normalizeCurrency(currency)
+	if cached, ok := p.cache.Get(ctx, req, currency); ok {
+		return handler(cached)
 	}
-	p.mu.RUnlock()
 
-	stream, err := p.client.SearchPriceFastCheckout(ctx, toPriceSearchRequest(req))
+	key := priceCacheKey(req, currency)
+	flight := p.flights.join(ctx, key, func(ctx context.Context, f *priceFlight) error {
+		return p.fetchPrices(ctx, req, currency, f)
+	})
+	defer p.flights.leave(key, flight)
+	return flight.relay(ctx, handler)
+}
+
+// fetchPrices runs one upstream stream, caching and publishing every response to the flight. A failed
+// upstream call is published as an error answer for the domain rather than failing the caller.
+func (p *PriceService) fetchPrices(ctx context.Context, req string, currency string, flight *priceFlight) error {
+	stream, err := p.client.SearchPriceFastCheckout(ctx, toPriceSearchRequest(req, currency))
 	if err != nil {
-		return fmt.Errorf("price service search: %w", err)
+		return p.publishFailure(ctx, fmt.Errorf("price service search: %w", err), flight.publish)
 	}
 
 	for {
@@ -66,44 +88,107 @@ func (p *PriceService) StreamPrices(ctx context.Context, req string, handler Pri
 			return nil
 		}
 		if err != nil {
-			return fmt.Errorf("price service stream recv: %w", err)
+			return p.publishFailure(ctx, fmt.Errorf("price service stream recv: %w", err), flight.publish)
 		}
 
 		if resp := fromPriceSearchResponse(req, msg); resp != nil {
 			if price := resp.GetPrice(); price != nil {
-				p.mu.Lock()
-				p.cache[cacheKey] = price
-				p.mu.Unlock()
-			}
-
-			if err := handler(resp); err != nil {
-				return err
+				if err := p.convert(ctx, price, currency); err != nil {
+					return err
+				}
 			}
+			p.cache.Set(ctx, req, currency, resp)
+			flight.publish(resp)
 		}
 	}
 }
 
+// publishFailure turns a failed upstream call into an error answer carrying its gRPC status, so that an
+// unhealthy price service fails the affected domains instead of the whole search. Failures are not cached,
+// and errors without a status, including the cancellation of ctx, are returned as they are.
+func (p *PriceService) publishFailure(ctx context.Context, err error, publish func(*domainsearchv1.SearchPricesResponse)) error {
+	resp := failureResponse(ctx, err)
+	if resp == nil {
+		return err
+	}
+	publish(resp)
+	return nil
+}
+
+// failureResponse returns the error answer for a failed upstream call, or nil when err carries no status or
+// ctx was cancelled.
+func failureResponse(ctx context.Context, err error) *domainsearchv1.SearchPricesResponse {
+	if ctx.Err() != nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+	return &domainsearchv1.SearchPricesResponse{
+		Response: &domainsearchv1.SearchPricesResponse_Error{Error: st.Proto()},
+	}
+}
+
 // ClearCache clears all cached price results
 func (p *PriceService) ClearCache() {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-	p.cache = make(map[string]*domainsearchv1.Price)
+	_, _ = p.cache.Clear(context.Background())
+}
+
+// Cache exposes the price cache for invalidation and metrics.
+func (p *PriceService) Cache() *PriceCache {
+	return p.cache
 }
 
-func toPriceSearchRequest(req string) *pricepb.SearchPricesRequest {
+// convert re-quotes the price when the upstream answered in a different currency than requested.
+func (p *PriceService) convert(ctx context.Context, price *domainsearchv1.Price, currency string) error {
+	return convertPrice(ctx, p.converter, price, currency)
+}
+
+// convertPrice re-quotes a price in currency when it differs from the quoted one. A nil converter, or one
+// without a rate for either currency, leaves the price in the currency it was quoted in and without a
+// conversion record; only other converter faults are returned.
+func convertPrice(ctx context.Context, converter CurrencyConverter, price *domainsearchv1.Price, currency string) error {
+	if converter == nil || price.GetCurrency() == "" || strings.EqualFold(price.GetCurrency(), currency) {
+		return nil
+	}
+	err := converter.ConvertPrice(ctx, price, currency)
+	if errors.Is(err, ErrNoRate) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("convert %s price to %s: %w", price.GetCurrency(), currency, err)
+	}
+	return nil
+}
+
+func normalizeCurrency(currency string) string {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		return DefaultCurrency
+	}
+	return currency
+}
+
+func toPriceSearchRequest(req string, currency string) *pricepb.SearchPricesRequest {
 	if req == "" {
 		return nil
 	}
 	domain, tld := extractTLD(req)
+	return priceSearchRequest(domain, []string{tld}, currency)
+}
+
+// priceSearchRequest asks for the prices of one label under every listed TLD.
+func priceSearchRequest(label string, tlds []string, currency string) *pricepb.SearchPricesRequest {
 	return &pricepb.SearchPricesRequest{
 		Product:      "domain",
-		Query:        domain,
-		CurrencyCode: "USD",
+		Query:        label,
+		CurrencyCode: currency,
 		Filter: &pricepb.PriceFilter{
 			Product: &pricepb.PriceFilter_Domain{
 				Domain: &pricepb.DomainPriceFilter{
 					TldFilter: &pricepb.DomainPriceFilter_IncludedTldNames{
-						IncludedTldNames: tld,
+						IncludedTldNames: strings.Join(tlds, ","),
 					},
 				},
 			},
@@ -141,14 +226,12 @@ func normalizePrice(domain string, data *pricepb.PriceData) *domainsearchv1.Pric
 	registration := pickProductPrice(data.Prices, registrationPricePriority)
 	renewal := pickProductPrice(data.Prices, renewalPricePriority)
 	price := &domainsearchv1.Price{
-		Domain:       domain,
-		Availability: true,
+		Domain:        domain,
+		UnicodeDomain: domainname.ToUnicode(domain),
 	}
-	price.Currency = registration.GetPrice().GetCurrencyCode()
-	price.Cost = toAmount(registration.GetPrice())
+	SetAmounts(price, registration.GetPrice().GetCurrencyCode(), upstreamAmount(registration.GetPrice()), upstreamAmount(renewal.GetPrice()))
 	price.Promotion = registration.GetPromotion() != nil
 	price.Labels = append([]string(nil), registration.Labels...)
-	price.RenewalCost = toAmount(renewal.GetPrice())
 
 	return price
 }
@@ -166,24 +249,15 @@ func pickProductPrice(source map[string]*pricepb.ProductPrice, key string) *pric
 	return nil
 }
 
-func toAmount(price *pricepb.Price) float32 {
-	if price == nil {
-		return 0
-	}
-	if val := price.GetValue(); val != "" {
-		if parsed, err := strconv.ParseFloat(val, 32); err == nil {
-			return float32(parsed)
-		}
-	}
-	return float32(price.GetUnits()) + float32(price.GetNanos())/1e9
-}
-
 func extractTLD(domain string) (string, string) {
 	clean := strings.TrimSpace(domain)
 	clean = strings.ToLower(clean)
 	if clean == "" {
 		return "", ""
 	}
+	if ascii, err := domainname.ToASCII(clean); err == nil {
+		clean = ascii
+	}
 	suffix, _ := publicsuffix.PublicSuffix(clean)
 	if suffix == "" {
 		return fallbackSplit(clean)
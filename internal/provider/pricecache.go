@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// PriceCacheConfig tunes the price cache. Zero values select the defaults.
+type PriceCacheConfig struct {
+	// TTL is how long a price stays fresh.
+	TTL time.Duration
+	// ErrorTTL is how long an error answer from the price service is replayed before asking again.
+	ErrorTTL time.Duration
+	// Namespace keeps the prices of several sources apart when they share a Cache.
+	Namespace string
+}
+
+const (
+	defaultPriceCacheTTL      = 15 * time.Minute
+	defaultPriceCacheErrorTTL = time.Minute
+	priceCacheKind            = "price"
+)
+
+// PriceCache stores price responses keyed by domain and currency in a Cache. Prices and errors are kept for
+// different periods. Failures of the underlying cache are counted and treated as misses.
+type PriceCache struct {
+	backend Cache
+	cfg     PriceCacheConfig
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+	errors atomic.Uint64
+}
+
+// NewPriceCache builds a price cache on top of backend. A nil backend selects an in-memory cache with the
+// default limits.
+func NewPriceCache(backend Cache, cfg PriceCacheConfig) *PriceCache {
+	if backend == nil {
+		backend = NewMemoryCache(0)
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = defaultPriceCacheTTL
+	}
+	if cfg.ErrorTTL <= 0 {
+		cfg.ErrorTTL = defaultPriceCacheErrorTTL
+	}
+	return &PriceCache{backend: backend, cfg: cfg}
+}
+
+// Get returns the cached response for the domain and currency, if one is still fresh.
+func (c *PriceCache) Get(ctx context.Context, domain, currency string) (*domainsearchv1.SearchPricesResponse, bool) {
+	resp, ok := c.load(ctx, c.key(domain, currency))
+	if ok {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+	return resp, ok
+}
+
+// Set stores the response. Error responses use the error TTL and never replace a fresh price.
+func (c *PriceCache) Set(ctx context.Context, domain, currency string, resp *domainsearchv1.SearchPricesResponse) {
+	if resp == nil || resp.GetResponse() == nil {
+		return
+	}
+	key := c.key(domain, currency)
+	ttl := c.cfg.TTL
+	if resp.GetError() != nil {
+		if cached, ok := c.load(ctx, key); ok && cached.GetPrice() != nil {
+			return
+		}
+		ttl = c.cfg.ErrorTTL
+	}
+
+	value, err := proto.Marshal(resp)
+	if err != nil {
+		c.errors.Add(1)
+		return
+	}
+	if err := c.backend.Set(ctx, key, value, ttl); err != nil {
+		c.errors.Add(1)
+	}
+}
+
+// InvalidateDomain drops the entries of a domain in every currency and namespace and returns how many were
+// removed.
+func (c *PriceCache) InvalidateDomain(ctx context.Context, domain string) (int, error) {
+	return c.backend.DeletePrefix(ctx, domainCacheKey(priceCacheKind, domain, ""))
+}
+
+// InvalidateTLD drops the entries of every domain under a TLD or public suffix, given with or without the
+// leading dot. A TLD also covers the suffixes under it: "uk" drops co.uk prices as well.
+func (c *PriceCache) InvalidateTLD(ctx context.Context, tld string) (int, error) {
+	return deleteSuffix(ctx, c.backend, priceCacheKind, tld)
+}
+
+// Clear drops every price entry, whatever its namespace, and returns how many were removed. Counters are kept.
+func (c *PriceCache) Clear(ctx context.Context) (int, error) {
+	return c.backend.DeletePrefix(ctx, priceCacheKind+"|")
+}
+
+// Stats returns the lookup counters.
+func (c *PriceCache) Stats() CacheLookupStats {
+	return CacheLookupStats{Hits: c.hits.Load(), Misses: c.misses.Load(), Errors: c.errors.Load()}
+}
+
+func (c *PriceCache) load(ctx context.Context, key string) (*domainsearchv1.SearchPricesResponse, bool) {
+	value, ok, err := c.backend.Get(ctx, key)
+	if err != nil {
+		c.errors.Add(1)
+		return nil, false
+	}
+	if !ok {
+		return nil, false
+	}
+	resp := &domainsearchv1.SearchPricesResponse{}
+	if err := proto.Unmarshal(value, resp); err != nil {
+		c.errors.Add(1)
+		return nil, false
+	}
+	return resp, true
+}
+
+// key places the namespace after the currency so that domain and TLD invalidation cover every source.
+func (c *PriceCache) key(domain, currency string) string {
+	variant := normalizeCurrency(currency)
+	if c.cfg.Namespace != "" {
+		variant += "|" + c.cfg.Namespace
+	}
+	return domainCacheKey(priceCacheKind, domain, variant)
+}
+
+func priceCacheKey(domain, currency string) string {
+	return domainCacheKey(priceCacheKind, domain, normalizeCurrency(currency))
+}
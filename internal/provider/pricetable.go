@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/olaysco/domain-search-llm/internal/domainname"
+	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
+	"github.com/olaysco/domain-search-llm/internal/periodic"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
+)
+
+// Supported price table formats.
+const (
+	PriceTableFormatCSV  = "csv"
+	PriceTableFormatJSON = "json"
+	PriceTableFormatYAML = "yaml"
+)
+
+// TLDPrice is one row of a price table. Amounts are in Currency; a zero transfer price means transfers are
+// not quoted.
+type TLDPrice struct {
+	TLD          string          `json:"tld" yaml:"tld"`
+	Currency     string          `json:"currency" yaml:"currency"`
+	Registration decimal.Decimal `json:"registration" yaml:"registration"`
+	Renewal      decimal.Decimal `json:"renewal" yaml:"renewal"`
+	Transfer     decimal.Decimal `json:"transfer" yaml:"transfer"`
+	Promotion    bool            `json:"promotion" yaml:"promotion"`
+}
+
+// PriceTableProvider implements PriceProvider with a static price list keyed by TLD, for running without the
+// upstream price service in development, CI and demos.
+type PriceTableProvider struct {
+	path      string
+	format    string
+	converter CurrencyConverter
+
+	mu      sync.RWMutex
+	prices  map[string]TLDPrice
+	modTime time.Time
+}
+
+// NewPriceTableProvider loads the price table at path. An empty format is inferred from the extension:
+// .csv, .json, or .yaml/.yml. The converter re-quotes prices requested in another currency and may be nil.
+func NewPriceTableProvider(path, format string, converter CurrencyConverter) (*PriceTableProvider, error) {
+	if format == "" {
+		format = priceTableFormatFromExtension(path)
+	}
+	switch format {
+	case PriceTableFormatCSV, PriceTableFormatJSON, PriceTableFormatYAML:
+	default:
+		return nil, fmt.Errorf("unsupported price table format %q", format)
+	}
+	p := &PriceTableProvider{path: path, format: format, converter: converter}
+	if err := p.Reload(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Reload reads the table again if the file changed since the last load. The previous table stays active
+// when the new one cannot be read.
+func (p *PriceTableProvider) Reload() error {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return fmt.Errorf("read price table: %w", err)
+	}
+	p.mu.RLock()
+	unchanged := p.prices != nil && info.ModTime().Equal(p.modTime)
+	p.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return fmt.Errorf("read price table: %w", err)
+	}
+	prices, err := parsePriceTable(data, p.format)
+	if err != nil {
+		return fmt.Errorf("%s: %w", p.path, err)
+	}
+	p.mu.Lock()
+	p.prices = prices
+	p.modTime = info.ModTime()
+	p.mu.Unlock()
+	return nil
+}
+
+// Run checks the file for changes every interval until ctx is cancelled. Reload failures are reported to
+// onError, which may be nil.
+func (p *PriceTableProvider) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	periodic.Run(ctx, interval, func(context.Context) error { return p.Reload() }, onError)
+}
+
+// StreamPrices implements PriceProvider with a single response: the price of the domain's TLD, or a
+// NotFound error when the table does not list it.
+func (p *PriceTableProvider) StreamPrices(ctx context.Context, req string, currency string, handler PriceStreamHandler) error {
+	if handler == nil {
+		return fmt.Errorf("price stream handler cannot be nil")
+	}
+	currency = normalizeCurrency(currency)
+	_, tld := extractTLD(req)
+
+	p.mu.RLock()
+	row, ok := p.prices[tld]
+	p.mu.RUnlock()
+	if !ok {
+		return handler(&domainsearchv1.SearchPricesResponse{
+			Response: &domainsearchv1.SearchPricesResponse_Error{
+				Error: status.Newf(codes.NotFound, "no price listed for .%s", tld).Proto(),
+			},
+		})
+	}
+
+	price := &domainsearchv1.Price{
+		Domain:        req,
+		UnicodeDomain: domainname.ToUnicode(req),
+		Promotion:     row.Promotion,
+	}
+	SetAmounts(price, row.Currency, row.Registration, row.Renewal)
+	if !row.Transfer.IsZero() {
+		price.Amounts.TransferCost = NewMoney(row.Transfer, row.Currency)
+	}
+	if err := convertPrice(ctx, p.converter, price, currency); err != nil {
+		return err
+	}
+	return handler(&domainsearchv1.SearchPricesResponse{
+		Response: &domainsearchv1.SearchPricesResponse_Price{Price: price},
+	})
+}
+
+// GetDomainPrice implements PriceSearchProvider with the price table entry of the domain's TLD.
+func (p *PriceTableProvider) GetDomainPrice(ctx context.Context, query string, currency string) ([]*Price, error) {
+	return CollectPrices(ctx, p, query, currency)
+}
+
+func priceTableFormatFromExtension(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return PriceTableFormatCSV
+	case ".yaml", ".yml":
+		return PriceTableFormatYAML
+	default:
+		return PriceTableFormatJSON
+	}
+}
+
+// priceTableDocument is the JSON and YAML layout: a default currency and one entry per TLD.
+//
+//	currency: USD
+//	tlds:
+//	  - {tld: com, registration: "9.99", renewal: "12.99", transfer: "9.99"}
+//	  - {tld: io, registration: "29.00", renewal: "39.00", promotion: true}
+type priceTableDocument struct {
+	Currency string     `json:"currency" yaml:"currency"`
+	TLDs     []TLDPrice `json:"tlds" yaml:"tlds"`
+}
+
+func parsePriceTable(data []byte, format string) (map[string]TLDPrice, error) {
+	var (
+		doc priceTableDocument
+		err error
+	)
+	switch format {
+	case PriceTableFormatCSV:
+		doc.TLDs, err = parsePriceTableCSV(data)
+	case PriceTableFormatYAML:
+		err = yaml.Unmarshal(data, &doc)
+	default:
+		err = json.Unmarshal(data, &doc)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decode price table: %w", err)
+	}
+
+	prices := make(map[string]TLDPrice, len(doc.TLDs))
+	for _, row := range doc.TLDs {
+		row.TLD = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(row.TLD)), ".")
+		if ascii, err := domainname.ToASCII(row.TLD); err == nil {
+			row.TLD = ascii
+		}
+		if row.TLD == "" {
+			return nil, fmt.Errorf("price table entry without tld")
+		}
+		if row.Currency == "" {
+			row.Currency = doc.Currency
+		}
+		row.Currency = normalizeCurrency(row.Currency)
+		if row.Registration.IsNegative() || row.Renewal.IsNegative() || row.Transfer.IsNegative() {
+			return nil, fmt.Errorf("price table entry for .%s has a negative price", row.TLD)
+		}
+		prices[row.TLD] = row
+	}
+	if len(prices) == 0 {
+		return nil, fmt.Errorf("price table contains no prices")
+	}
+	return prices, nil
+}
+
+// parsePriceTableCSV reads rows under a header naming the columns: tld and registration are required,
+// renewal, transfer, promotion and currency are optional.
+func parsePriceTableCSV(data []byte) ([]TLDPrice, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"tld", "registration"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("csv header is missing the %q column", required)
+		}
+	}
+
+	rows := make([]TLDPrice, 0, len(records)-1)
+	for n, record := range records[1:] {
+		line := n + 2
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		amount := func(name string) (decimal.Decimal, error) {
+			raw := field(name)
+			if raw == "" {
+				return decimal.Zero, nil
+			}
+			value, err := decimal.NewFromString(raw)
+			if err != nil {
+				return decimal.Zero, fmt.Errorf("line %d: %s: %w", line, name, err)
+			}
+			return value, nil
+		}
+
+		row := TLDPrice{TLD: field("tld"), Currency: field("currency")}
+		if row.Registration, err = amount("registration"); err != nil {
+			return nil, err
+		}
+		if row.Renewal, err = amount("renewal"); err != nil {
+			return nil, err
+		}
+		if row.Transfer, err = amount("transfer"); err != nil {
+			return nil, err
+		}
+		if raw := field("promotion"); raw != "" {
+			if row.Promotion, err = strconv.ParseBool(raw); err != nil {
+				return nil, fmt.Errorf("line %d: promotion: %w", line, err)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
+	"google.golang.org/grpc/codes"
+)
+
+// tablePrice describes the answer of a price table for one domain, or the status code of its error answer.
+func tablePrice(t *testing.T, p *PriceTableProvider, domain string) string {
+	t.Helper()
+	var got string
+	err := p.StreamPrices(context.Background(), domain, "", func(resp *domainsearchv1.SearchPricesResponse) error {
+		price := resp.GetPrice()
+		if price == nil {
+			got = codes.Code(resp.GetError().GetCode()).String()
+			return nil
+		}
+		got = price.GetCurrency() + " " + CostOf(price).String() + "/" + RenewalCostOf(price).String()
+		if transfer := price.GetAmounts().GetTransferCost(); transfer != nil {
+			got += " transfer " + MoneyAmount(transfer).String()
+		}
+		if price.GetPromotion() {
+			got += " promotion"
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamPrices(%q): %v", domain, err)
+	}
+	return got
+}
+
+func writePriceTable(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPriceTableProviderFormats(t *testing.T) {
+	tests := map[string]string{
+		"prices.csv": `# tld prices
+tld,registration,renewal,transfer,promotion,currency
+.COM, 9.99, 12.99, 9.99, false,
+io,29,39,,true,usd
+рф,5.5,7,,,EUR
+`,
+		"prices.json": `{"currency": "usd", "tlds": [
+	{"tld": ".COM", "registration": "9.99", "renewal": "12.99", "transfer": "9.99"},
+	{"tld": "io", "registration": "29", "renewal": "39", "promotion": true},
+	{"tld": "рф", "currency": "EUR", "registration": "5.5", "renewal": "7"}
+]}`,
+		"prices.yml": `currency: USD
+tlds:
+  - {tld: .COM, registration: "9.99", renewal: "12.99", transfer: "9.99"}
+  - {tld: io, registration: "29", renewal: "39", promotion: true}
+  - {tld: рф, currency: eur, registration: "5.5", renewal: "7"}
+`,
+	}
+	want := map[string]string{
+		"brand.com": "USD 9.99/12.99 transfer 9.99",
+		"brand.io":  "USD 29/39 promotion",
+		"пример.рф": "EUR 5.5/7",
+		"brand.net": "NotFound",
+	}
+	for name, content := range tests {
+		path := filepath.Join(t.TempDir(), name)
+		writePriceTable(t, path, content)
+		p, err := NewPriceTableProvider(path, "", nil)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for domain, price := range want {
+			if got := tablePrice(t, p, domain); got != price {
+				t.Errorf("%s: %s = %q, want %q", name, domain, got, price)
+			}
+		}
+	}
+}
+
+func TestPriceTableProviderRejectsInvalidTables(t *testing.T) {
+	tests := map[string]string{
+		"missing column.csv": "tld,renewal\ncom,12.99\n",
+		"bad amount.csv":     "tld,registration\ncom,cheap\n",
+		"bad promotion.csv":  "tld,registration,promotion\ncom,9.99,maybe\n",
+		"negative.json":      `{"tlds": [{"tld": "com", "registration": "-1"}]}`,
+		"no tld.yaml":        "tlds:\n  - {registration: \"9.99\"}\n",
+		"empty.json":         `{"tlds": []}`,
+	}
+	for name, content := range tests {
+		path := filepath.Join(t.TempDir(), name)
+		writePriceTable(t, path, content)
+		if _, err := NewPriceTableProvider(path, "", nil); err == nil {
+			t.Errorf("%s: NewPriceTableProvider accepted the table", name)
+		}
+	}
+	if _, err := NewPriceTableProvider("prices.toml", "toml", nil); err == nil {
+		t.Error("NewPriceTableProvider accepted an unsupported format")
+	}
+}
+
+func TestPriceTableProviderReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prices.csv")
+	writePriceTable(t, path, "tld,registration\ncom,9.99\n")
+	p, err := NewPriceTableProvider(path, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modified := time.Now().Add(time.Minute)
+	writePriceTable(t, path, "tld,registration\ncom,10.99\nio,29\n")
+	if err := os.Chtimes(path, modified, modified); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if got := tablePrice(t, p, "brand.com"); got != "USD 10.99/0" {
+		t.Errorf("brand.com after reload = %q, want USD 10.99/0", got)
+	}
+	if got := tablePrice(t, p, "brand.io"); got != "USD 29/0" {
+		t.Errorf("brand.io after reload = %q, want USD 29/0", got)
+	}
+
+	broken := modified.Add(time.Minute)
+	writePriceTable(t, path, "tld,registration\ncom,free\n")
+	if err := os.Chtimes(path, broken, broken); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Reload(); err == nil {
+		t.Error("Reload accepted a broken table")
+	}
+	if got := tablePrice(t, p, "brand.com"); got != "USD 10.99/0" {
+		t.Errorf("brand.com after a failed reload = %q, want the previous table's USD 10.99/0", got)
+	}
+}
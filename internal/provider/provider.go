@@ -1,9 +1,22 @@
 package provider
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"time"
 
+	domainsearchv1 "github.com/olaysco/domain-search-llm/internal/gen/domainsearch/v1"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/status"
+)
+
+// defaultPriceLookupTimeout bounds GetDomainPrice when the caller's context has no deadline.
+const defaultPriceLookupTimeout = 15 * time.Second
+
+// PriceSearchProvider is a unary alternative to PriceProvider for callers that want all prices of a domain
+// at once rather than a stream.
 type PriceSearchProvider interface {
-	// GetRegularPrice retrieves the single domain price from provider.
+	// GetDomainPrice retrieves the prices of a single domain from the provider.
 	GetDomainPrice(ctx context.Context, query string, currency string) ([]*Price, error)
 }
 
@@ -11,18 +24,74 @@ type PriceSearchProvider interface {
 type Price struct {
 	// Promotion is promotion available.
 	Promotion bool `json:"promotion"`
-	// Cost is cost value.
-	Cost float32 `json:"cost"`
+	// Cost is the registration cost.
+	Cost decimal.Decimal `json:"cost"`
 	// Currency is the 3-letter currency code defined in ISO 4217.
 	Currency string `json:"currency"`
 	// Domain is full domain name with tld.
 	Domain string `json:"domain"`
 	// Labels is array of domain labels.
 	Labels []string `json:"labels"`
-	// Availability is domain taken.
+	// Availability reports whether the domain is known to be available.
 	Availability bool `json:"availability"`
-	//SimilarityScore is similarity with user query
+	// SimilarityScore is similarity with user query.
 	SimilarityScore float64 `json:"similarity_score"`
-	//Renewalcost is domain renew cost
-	Renewalcost float32 `json:"renewal_cost"`
+	// RenewalCost is domain renew cost.
+	RenewalCost decimal.Decimal `json:"renewal_cost"`
+	// Provider names the price source that quoted the domain, when several are aggregated.
+	Provider string `json:"provider,omitempty"`
+}
+
+// GetDomainPrice implements PriceSearchProvider by collecting the price stream of the domain.
+func (p *PriceService) GetDomainPrice(ctx context.Context, query string, currency string) ([]*Price, error) {
+	return CollectPrices(ctx, p, query, currency)
+}
+
+// CollectPrices runs a price stream to completion and returns every price it carried. The lookup is bounded
+// by a default deadline unless ctx already has one. An error answer fails the call only when the stream
+// carried no price at all.
+func CollectPrices(ctx context.Context, provider PriceProvider, query string, currency string) ([]*Price, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultPriceLookupTimeout)
+		defer cancel()
+	}
+
+	var (
+		prices    []*Price
+		answerErr error
+	)
+	err := provider.StreamPrices(ctx, query, currency, func(resp *domainsearchv1.SearchPricesResponse) error {
+		if price := resp.GetPrice(); price != nil {
+			prices = append(prices, fromAPIPrice(price))
+		} else if st := resp.GetError(); st != nil && answerErr == nil {
+			answerErr = status.ErrorProto(st)
+		}
+		return nil
+	})
+	if err != nil {
+		return prices, err
+	}
+	if len(prices) == 0 {
+		if answerErr != nil {
+			return nil, answerErr
+		}
+		return nil, fmt.Errorf("no price found for %s", query)
+	}
+	return prices, nil
+}
+
+// fromAPIPrice flattens a price of the public API into a Price. Labels are only set when the upstream sent some.
+func fromAPIPrice(price *domainsearchv1.Price) *Price {
+	return &Price{
+		Promotion:       price.GetPromotion(),
+		Cost:            CostOf(price),
+		Currency:        price.GetCurrency(),
+		Domain:          price.GetDomain(),
+		Labels:          price.GetLabels(),
+		Availability:    price.GetAvailability(),
+		SimilarityScore: price.GetSimilarityScore(),
+		RenewalCost:     RenewalCostOf(price),
+		Provider:        price.GetProvider(),
+	}
 }
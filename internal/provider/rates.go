@@ -0,0 +1,296 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Supported exchange-rate document formats.
+const (
+	RatesFormatJSON = "json"
+	RatesFormatCSV  = "csv"
+	RatesFormatECB  = "ecb"
+)
+
+// ExchangeRates is a snapshot of currency rates sharing a common base. Only the ratio between two rates
+// matters, so the base currency itself does not need to be known.
+type ExchangeRates struct {
+	Rates     map[string]decimal.Decimal
+	UpdatedAt time.Time
+}
+
+// RateSource loads the current exchange rates.
+type RateSource interface {
+	LoadRates(ctx context.Context) (*ExchangeRates, error)
+}
+
+// NewRateSource builds a RateSource for a file path or an http(s) URL. An empty format is inferred from the
+// extension: .json, .csv, or .xml for the ECB euro reference rates.
+func NewRateSource(location, format string) (RateSource, error) {
+	location = strings.TrimSpace(location)
+	if location == "" {
+		return nil, fmt.Errorf("exchange rate source is required")
+	}
+	if format == "" {
+		format = ratesFormatFromExtension(location)
+	}
+	switch format {
+	case RatesFormatJSON, RatesFormatCSV, RatesFormatECB:
+	default:
+		return nil, fmt.Errorf("unsupported exchange rate format %q", format)
+	}
+
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		return &HTTPRateSource{URL: location, Format: format, Client: &http.Client{Timeout: 10 * time.Second}}, nil
+	}
+	return &FileRateSource{Path: location, Format: format}, nil
+}
+
+// FileRateSource reads exchange rates from a local file.
+type FileRateSource struct {
+	Path   string
+	Format string
+}
+
+// LoadRates implements RateSource. Documents without a timestamp are dated by the file modification time.
+func (s *FileRateSource) LoadRates(_ context.Context) (*ExchangeRates, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, fmt.Errorf("read exchange rates: %w", err)
+	}
+	rates, err := parseRates(data, s.Format)
+	if err != nil {
+		return nil, err
+	}
+	if rates.UpdatedAt.IsZero() {
+		if info, err := os.Stat(s.Path); err == nil {
+			rates.UpdatedAt = info.ModTime().UTC()
+		}
+	}
+	return rates, nil
+}
+
+// HTTPRateSource downloads exchange rates from an HTTP endpoint.
+type HTTPRateSource struct {
+	URL    string
+	Format string
+	Client *http.Client
+}
+
+// LoadRates implements RateSource. Documents without a timestamp are dated by the Last-Modified header.
+func (s *HTTPRateSource) LoadRates(ctx context.Context) (*ExchangeRates, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("exchange rates request: %w", err)
+	}
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download exchange rates: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download exchange rates: unexpected status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("download exchange rates: %w", err)
+	}
+
+	rates, err := parseRates(data, s.Format)
+	if err != nil {
+		return nil, err
+	}
+	if rates.UpdatedAt.IsZero() {
+		if modified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+			rates.UpdatedAt = modified.UTC()
+		}
+	}
+	return rates, nil
+}
+
+func ratesFormatFromExtension(location string) string {
+	if idx := strings.IndexAny(location, "?#"); idx != -1 {
+		location = location[:idx]
+	}
+	switch strings.ToLower(filepath.Ext(location)) {
+	case ".csv":
+		return RatesFormatCSV
+	case ".xml":
+		return RatesFormatECB
+	default:
+		return RatesFormatJSON
+	}
+}
+
+func parseRates(data []byte, format string) (*ExchangeRates, error) {
+	var (
+		rates *ExchangeRates
+		err   error
+	)
+	switch format {
+	case RatesFormatCSV:
+		rates, err = parseCSVRates(data)
+	case RatesFormatECB:
+		rates, err = parseECBRates(data)
+	default:
+		rates, err = parseJSONRates(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rates.Rates) == 0 {
+		return nil, fmt.Errorf("exchange rate document contains no rates")
+	}
+	return rates, nil
+}
+
+// parseJSONRates reads documents shaped like {"base": "EUR", "date": "2025-01-15", "rates": {"USD": 1.03}}.
+// The timestamp may be given as "timestamp" (unix seconds), "date" (YYYY-MM-DD) or "updated_at" (RFC 3339).
+func parseJSONRates(data []byte) (*ExchangeRates, error) {
+	var doc struct {
+		Base      string                     `json:"base"`
+		Timestamp int64                      `json:"timestamp"`
+		Date      string                     `json:"date"`
+		UpdatedAt time.Time                  `json:"updated_at"`
+		Rates     map[string]decimal.Decimal `json:"rates"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decode exchange rates: %w", err)
+	}
+
+	rates := &ExchangeRates{Rates: make(map[string]decimal.Decimal, len(doc.Rates)+1), UpdatedAt: doc.UpdatedAt}
+	for currency, rate := range doc.Rates {
+		if err := rates.add(currency, rate); err != nil {
+			return nil, err
+		}
+	}
+	if doc.Base != "" {
+		if err := rates.add(doc.Base, decimal.NewFromInt(1)); err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case doc.Timestamp > 0:
+		rates.UpdatedAt = time.Unix(doc.Timestamp, 0).UTC()
+	case doc.Date != "":
+		date, err := time.Parse(time.DateOnly, doc.Date)
+		if err != nil {
+			return nil, fmt.Errorf("decode exchange rates date: %w", err)
+		}
+		rates.UpdatedAt = date
+	}
+	return rates, nil
+}
+
+// parseCSVRates reads "currency,rate[,date]" rows. A header row is skipped.
+func parseCSVRates(data []byte) (*ExchangeRates, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("decode exchange rates csv: %w", err)
+	}
+
+	rates := &ExchangeRates{Rates: make(map[string]decimal.Decimal, len(records))}
+	for i, record := range records {
+		if len(record) < 2 {
+			return nil, fmt.Errorf("exchange rates csv line %d: expected currency,rate", i+1)
+		}
+		rate, err := decimal.NewFromString(strings.TrimSpace(record[1]))
+		if err != nil {
+			if i == 0 {
+				continue // header
+			}
+			return nil, fmt.Errorf("exchange rates csv line %d: %w", i+1, err)
+		}
+		if err := rates.add(record[0], rate); err != nil {
+			return nil, fmt.Errorf("exchange rates csv line %d: %w", i+1, err)
+		}
+		if len(record) > 2 && rates.UpdatedAt.IsZero() {
+			if date, err := time.Parse(time.DateOnly, strings.TrimSpace(record[2])); err == nil {
+				rates.UpdatedAt = date
+			}
+		}
+	}
+	return rates, nil
+}
+
+// parseECBRates reads the European Central Bank euro foreign exchange reference rates
+// (eurofxref-daily.xml). The euro is added with a rate of 1.
+func parseECBRates(data []byte) (*ExchangeRates, error) {
+	var doc struct {
+		Cube struct {
+			Cube []struct {
+				Time  string `xml:"time,attr"`
+				Rates []struct {
+					Currency string `xml:"currency,attr"`
+					Rate     string `xml:"rate,attr"`
+				} `xml:"Cube"`
+			} `xml:"Cube"`
+		} `xml:"Cube"`
+	}
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decode ecb exchange rates: %w", err)
+	}
+	if len(doc.Cube.Cube) == 0 {
+		return nil, errors.New("ecb exchange rates contain no daily cube")
+	}
+
+	// The daily file holds one cube; historical files list the most recent day first.
+	daily := doc.Cube.Cube[0]
+	rates := &ExchangeRates{Rates: make(map[string]decimal.Decimal, len(daily.Rates)+1)}
+	if date, err := time.Parse(time.DateOnly, daily.Time); err == nil {
+		rates.UpdatedAt = date
+	}
+	for _, r := range daily.Rates {
+		rate, err := decimal.NewFromString(r.Rate)
+		if err != nil {
+			return nil, fmt.Errorf("ecb rate for %s: %w", r.Currency, err)
+		}
+		if err := rates.add(r.Currency, rate); err != nil {
+			return nil, err
+		}
+	}
+	if err := rates.add("EUR", decimal.NewFromInt(1)); err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+func (r *ExchangeRates) add(currency string, rate decimal.Decimal) error {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if len(currency) != 3 {
+		return fmt.Errorf("invalid currency code %q", currency)
+	}
+	if !rate.IsPositive() {
+		return fmt.Errorf("rate for %s must be positive, got %s", currency, rate)
+	}
+	r.Rates[currency] = rate
+	return nil
+}
+
+// minorUnits returns the number of decimals used by a currency.
+func minorUnits(currency string) int32 {
+	switch currency {
+	case "JPY", "KRW", "VND", "CLP", "ISK":
+		return 0
+	case "BHD", "KWD", "OMR", "JOD", "TND":
+		return 3
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisKeyPrefix = "domainsearch:"
+
+// RedisCache is a Cache stored in Redis or any server speaking its protocol, shared by every replica.
+// Keys are namespaced under "domainsearch:" and expire through the server's own TTL.
+type RedisCache struct {
+	client *redis.Client
+}
+
+// NewRedisCache connects to the server described by a redis:// or rediss:// URL.
+func NewRedisCache(url string) (*RedisCache, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("parse redis url: %w", err)
+	}
+	return &RedisCache{client: redis.NewClient(opts)}, nil
+}
+
+// Get implements Cache.
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := c.client.Get(ctx, redisKeyPrefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("redis cache get: %w", err)
+	}
+	return value, true, nil
+}
+
+// Set implements Cache.
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := c.client.Set(ctx, redisKeyPrefix+key, value, ttl).Err(); err != nil {
+		return fmt.Errorf("redis cache set: %w", err)
+	}
+	return nil
+}
+
+// DeletePrefix implements Cache by scanning the matching keys, which is O(n) on the server keyspace.
+func (c *RedisCache) DeletePrefix(ctx context.Context, prefix string) (int, error) {
+	removed := 0
+	iter := c.client.Scan(ctx, 0, redisKeyPrefix+escapeRedisPattern(prefix)+"*", 500).Iterator()
+	var batch []string
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		n, err := c.client.Del(ctx, batch...).Result()
+		if err != nil {
+			return fmt.Errorf("redis cache delete: %w", err)
+		}
+		removed += int(n)
+		batch = batch[:0]
+		return nil
+	}
+	for iter.Next(ctx) {
+		batch = append(batch, iter.Val())
+		if len(batch) == 500 {
+			if err := flush(); err != nil {
+				return removed, err
+			}
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return removed, fmt.Errorf("redis cache scan: %w", err)
+	}
+	return removed, flush()
+}
+
+// Close implements Cache.
+func (c *RedisCache) Close() error {
+	return c.client.Close()
+}
+
+// escapeRedisPattern escapes the glob characters understood by SCAN MATCH.
+func escapeRedisPattern(s string) string {
+	out := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '*', '?', '[', ']', '\\':
+			out = append(out, '\\')
+		}
+		out = append(out, s[i])
+	}
+	return string(out)
+}
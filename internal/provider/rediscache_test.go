@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func newTestRedisCache(t *testing.T) (*RedisCache, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	c, err := NewRedisCache("redis://" + server.Addr() + "/0")
+	if err != nil {
+		t.Fatalf("NewRedisCache: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c, server
+}
+
+func TestRedisCache(t *testing.T) {
+	c, server := newTestRedisCache(t)
+	testCacheBackend(t, c, server.FastForward)
+
+	if !server.Exists(redisKeyPrefix + "price|com|brand.com|USD") {
+		t.Errorf("keys are not stored under the %q namespace: %v", redisKeyPrefix, server.Keys())
+	}
+}
+
+func TestRedisCacheCountsServerErrors(t *testing.T) {
+	c, server := newTestRedisCache(t)
+	prices := NewPriceCache(c, PriceCacheConfig{})
+	server.Close()
+
+	if _, ok := prices.Get(context.Background(), "down.com", "USD"); ok {
+		t.Fatal("unexpected hit without a server")
+	}
+	if stats := prices.Stats(); stats.Errors != 1 || stats.Misses != 1 {
+		t.Fatalf("Stats = %+v, want 1 error and 1 miss", stats)
+	}
+}
@@ -0,0 +1,89 @@
+package rdap
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// bundledBootstrap lists the RDAP servers of a handful of common TLDs. It is not a copy of the IANA
+// registry; the client only falls back to it while DefaultBootstrapSource cannot be loaded.
+//
+//go:embed dns.json
+var bundledBootstrap []byte
+
+// Bootstrap maps TLDs to the RDAP base URLs that serve them, as described in RFC 9224.
+type Bootstrap struct {
+	Publication string
+	services    map[string][]string
+}
+
+// ParseBootstrap decodes an IANA DNS bootstrap document.
+func ParseBootstrap(data []byte) (*Bootstrap, error) {
+	var doc struct {
+		Publication string       `json:"publication"`
+		Services    [][][]string `json:"services"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decode rdap bootstrap: %w", err)
+	}
+
+	b := &Bootstrap{
+		Publication: doc.Publication,
+		services:    make(map[string][]string),
+	}
+	for _, service := range doc.Services {
+		if len(service) != 2 {
+			return nil, fmt.Errorf("rdap bootstrap service entry has %d elements, want 2", len(service))
+		}
+		urls := make([]string, 0, len(service[1]))
+		for _, u := range service[1] {
+			if !strings.HasSuffix(u, "/") {
+				u += "/"
+			}
+			urls = append(urls, u)
+		}
+		// RFC 9224 asks clients to prefer HTTPS endpoints when both are listed.
+		for i, u := range urls {
+			if strings.HasPrefix(u, "https://") {
+				urls[0], urls[i] = urls[i], urls[0]
+				break
+			}
+		}
+		for _, tld := range service[0] {
+			b.services[strings.ToLower(tld)] = urls
+		}
+	}
+	if len(b.services) == 0 {
+		return nil, fmt.Errorf("rdap bootstrap contains no services")
+	}
+	return b, nil
+}
+
+// BaseURL returns the RDAP base URL responsible for the domain. The public suffix is tried first and then
+// every shorter suffix, so "example.co.uk" resolves through "co.uk" and falls back to "uk".
+func (b *Bootstrap) BaseURL(domain string) (string, bool) {
+	domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+	suffix, _ := publicsuffix.PublicSuffix(domain)
+	if suffix == "" {
+		return "", false
+	}
+	for {
+		if urls := b.services[suffix]; len(urls) > 0 {
+			return urls[0], true
+		}
+		idx := strings.Index(suffix, ".")
+		if idx == -1 {
+			return "", false
+		}
+		suffix = suffix[idx+1:]
+	}
+}
+
+// Len reports the number of TLDs covered by the bootstrap.
+func (b *Bootstrap) Len() int {
+	return len(b.services)
+}
@@ -0,0 +1,169 @@
+package rdap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/olaysco/domain-search-llm/internal/periodic"
+	"github.com/olaysco/domain-search-llm/internal/provider"
+)
+
+var (
+	// ErrNoServer is returned when the bootstrap has no RDAP server for the domain's TLD.
+	ErrNoServer = errors.New("rdap: no server for tld")
+	// ErrRateLimited is returned when the RDAP server answered 429 Too Many Requests.
+	ErrRateLimited = errors.New("rdap: rate limited")
+)
+
+// DefaultBootstrapSource is the live IANA registry of RDAP servers for DNS registrations.
+const DefaultBootstrapSource = "https://data.iana.org/rdap/dns.json"
+
+// Config controls where the bootstrap registry comes from and how RDAP servers are queried.
+type Config struct {
+	// BootstrapSource is a file path or http(s) URL of an IANA dns.json document. Defaults to
+	// DefaultBootstrapSource.
+	BootstrapSource string
+	// OnFallback is called with the load error when the initial bootstrap cannot be loaded from
+	// BootstrapSource and the bundled fallback list is used instead. May be nil.
+	OnFallback func(error)
+	// RefreshInterval reloads the bootstrap periodically while Run is active. Zero disables refreshing.
+	RefreshInterval time.Duration
+	// HTTPClient is used for bootstrap downloads and RDAP queries. Defaults to a client with a 10s timeout.
+	HTTPClient *http.Client
+}
+
+// Client resolves domain availability through the RDAP server responsible for each TLD.
+type Client struct {
+	cfg        Config
+	httpClient *http.Client
+
+	mu        sync.RWMutex
+	bootstrap *Bootstrap
+}
+
+// New builds a Client and loads the initial bootstrap registry. When the source cannot be loaded the
+// bundled fallback list is used until a later Refresh succeeds.
+func New(cfg Config) (*Client, error) {
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	if strings.TrimSpace(cfg.BootstrapSource) == "" {
+		cfg.BootstrapSource = DefaultBootstrapSource
+	}
+	c := &Client{cfg: cfg, httpClient: httpClient}
+	if err := c.Refresh(context.Background()); err != nil {
+		bootstrap, fallbackErr := ParseBootstrap(bundledBootstrap)
+		if fallbackErr != nil {
+			return nil, errors.Join(err, fallbackErr)
+		}
+		c.bootstrap = bootstrap
+		if cfg.OnFallback != nil {
+			cfg.OnFallback(err)
+		}
+	}
+	return c, nil
+}
+
+// Refresh reloads the bootstrap registry from the configured source. The previous registry stays active
+// when loading fails.
+func (c *Client) Refresh(ctx context.Context) error {
+	data, err := c.loadBootstrap(ctx)
+	if err != nil {
+		return err
+	}
+	bootstrap, err := ParseBootstrap(data)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.bootstrap = bootstrap
+	c.mu.Unlock()
+	return nil
+}
+
+// Run refreshes the bootstrap every RefreshInterval until ctx is cancelled. Refresh failures are reported
+// to onError, which may be nil.
+func (c *Client) Run(ctx context.Context, onError func(error)) {
+	periodic.Run(ctx, c.cfg.RefreshInterval, c.Refresh, onError)
+}
+
+// Bootstrap returns the registry currently in use.
+func (c *Client) Bootstrap() *Bootstrap {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.bootstrap
+}
+
+// CheckAvailability implements provider.AvailabilityChecker. A 404 from the responsible RDAP server means
+// the domain is available and a 200 means it is registered; everything else is unknown and comes with an error.
+func (c *Client) CheckAvailability(ctx context.Context, domain string) (provider.Availability, error) {
+	domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+	if domain == "" {
+		return provider.AvailabilityUnknown, fmt.Errorf("rdap: domain is required")
+	}
+
+	baseURL, ok := c.Bootstrap().BaseURL(domain)
+	if !ok {
+		return provider.AvailabilityUnknown, fmt.Errorf("%w: %s", ErrNoServer, domain)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"domain/"+url.PathEscape(domain), nil)
+	if err != nil {
+		return provider.AvailabilityUnknown, fmt.Errorf("rdap request: %w", err)
+	}
+	req.Header.Set("Accept", "application/rdap+json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return provider.AvailabilityUnknown, fmt.Errorf("rdap lookup %s: %w", domain, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return provider.AvailabilityTaken, nil
+	case http.StatusNotFound:
+		return provider.AvailabilityAvailable, nil
+	case http.StatusTooManyRequests:
+		return provider.AvailabilityUnknown, fmt.Errorf("%w: %s (retry after %q)", ErrRateLimited, domain, resp.Header.Get("Retry-After"))
+	default:
+		return provider.AvailabilityUnknown, fmt.Errorf("rdap lookup %s: unexpected status %d", domain, resp.StatusCode)
+	}
+}
+
+func (c *Client) loadBootstrap(ctx context.Context) ([]byte, error) {
+	source := strings.TrimSpace(c.cfg.BootstrapSource)
+	switch {
+	case strings.HasPrefix(source, "http://"), strings.HasPrefix(source, "https://"):
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+		if err != nil {
+			return nil, fmt.Errorf("rdap bootstrap request: %w", err)
+		}
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("rdap bootstrap download: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("rdap bootstrap download: unexpected status %d", resp.StatusCode)
+		}
+		return io.ReadAll(resp.Body)
+	default:
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("rdap bootstrap read: %w", err)
+		}
+		return data, nil
+	}
+}
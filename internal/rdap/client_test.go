@@ -0,0 +1,151 @@
+package rdap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/olaysco/domain-search-llm/internal/provider"
+)
+
+// registry serves a bootstrap document that can be swapped between refreshes.
+type registry struct {
+	mu   sync.Mutex
+	doc  string
+	fail bool
+}
+
+func (r *registry) set(doc string, fail bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.doc, r.fail = doc, fail
+}
+
+func (r *registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.fail {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	fmt.Fprint(w, r.doc)
+}
+
+func bootstrapFor(tld, baseURL string) string {
+	return fmt.Sprintf(`{"publication":"test","services":[[[%q],[%q]]]}`, tld, baseURL)
+}
+
+// newRDAPServer answers 200 for taken.com, 429 for busy.com and 404 for everything else.
+func newRDAPServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/domain/taken.com":
+			fmt.Fprint(w, `{"objectClassName":"domain"}`)
+		case "/domain/busy.com":
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestClient(t *testing.T, reg *registry) *Client {
+	t.Helper()
+	bootstrapSrv := httptest.NewServer(reg)
+	t.Cleanup(bootstrapSrv.Close)
+	c, err := New(Config{
+		BootstrapSource: bootstrapSrv.URL,
+		OnFallback: func(err error) {
+			t.Fatalf("unexpected fallback: %v", err)
+		},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return c
+}
+
+func TestCheckAvailability(t *testing.T) {
+	rdapSrv := newRDAPServer(t)
+	c := newTestClient(t, &registry{doc: bootstrapFor("com", rdapSrv.URL)})
+
+	tests := []struct {
+		domain  string
+		want    provider.Availability
+		wantErr error
+	}{
+		{domain: "free.com", want: provider.AvailabilityAvailable},
+		{domain: "Taken.COM.", want: provider.AvailabilityTaken},
+		{domain: "busy.com", want: provider.AvailabilityUnknown, wantErr: ErrRateLimited},
+		{domain: "free.dev", want: provider.AvailabilityUnknown, wantErr: ErrNoServer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.domain, func(t *testing.T) {
+			got, err := c.CheckAvailability(context.Background(), tt.domain)
+			if got != tt.want {
+				t.Errorf("availability = %v, want %v", got, tt.want)
+			}
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRefreshReloadsBootstrap(t *testing.T) {
+	rdapSrv := newRDAPServer(t)
+	reg := &registry{doc: bootstrapFor("com", rdapSrv.URL)}
+	c := newTestClient(t, reg)
+
+	if _, err := c.CheckAvailability(context.Background(), "free.dev"); !errors.Is(err, ErrNoServer) {
+		t.Fatalf("error = %v, want ErrNoServer before reload", err)
+	}
+
+	reg.set(bootstrapFor("dev", rdapSrv.URL), false)
+	if err := c.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	got, err := c.CheckAvailability(context.Background(), "free.dev")
+	if err != nil || got != provider.AvailabilityAvailable {
+		t.Fatalf("after reload got %v, %v; want available", got, err)
+	}
+
+	reg.set("", true)
+	if err := c.Refresh(context.Background()); err == nil {
+		t.Fatal("Refresh succeeded against a failing registry")
+	}
+	if _, ok := c.Bootstrap().BaseURL("free.dev"); !ok {
+		t.Fatal("a failed refresh dropped the previous bootstrap")
+	}
+}
+
+func TestNewFallsBackToBundledBootstrap(t *testing.T) {
+	bootstrapSrv := httptest.NewServer(&registry{fail: true})
+	defer bootstrapSrv.Close()
+
+	var fallbackErr error
+	c, err := New(Config{
+		BootstrapSource: bootstrapSrv.URL,
+		OnFallback:      func(err error) { fallbackErr = err },
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if fallbackErr == nil {
+		t.Fatal("OnFallback was not called")
+	}
+	if _, ok := c.Bootstrap().BaseURL("example.com"); !ok {
+		t.Fatal("bundled bootstrap does not cover .com")
+	}
+}
@@ -0,0 +1,169 @@
+package scoring
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/olaysco/domain-search-llm/internal/domainname"
+	"golang.org/x/net/publicsuffix"
+)
+
+// Weights controls how much each signal contributes to the final score. Signals with a zero weight are skipped
+// and the remaining weights are normalized, so they do not need to sum to one.
+type Weights struct {
+	Lexical          float64
+	Length           float64
+	Pronounceability float64
+	TLD              float64
+	Semantic         float64
+}
+
+// DefaultWeights favours names that reuse the query's words or meaning and sit on a fitting TLD.
+// The semantic weight only applies when the Scorer has a SemanticSimilarity.
+func DefaultWeights() Weights {
+	return Weights{
+		Lexical:          0.3,
+		Length:           0.1,
+		Pronounceability: 0.15,
+		TLD:              0.2,
+		Semantic:         0.25,
+	}
+}
+
+// ParseWeights reads a comma separated list of name=value pairs, e.g. "lexical=0.5,tld=0.2,semantic=0.3".
+// Signals missing from the list keep their default weight. Weights cannot be negative, and at least one must
+// stay positive.
+func ParseWeights(spec string) (Weights, error) {
+	weights := DefaultWeights()
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, raw, ok := strings.Cut(pair, "=")
+		if !ok {
+			return Weights{}, fmt.Errorf("invalid weight %q, expected name=value", pair)
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil || value < 0 {
+			return Weights{}, fmt.Errorf("invalid weight value for %q: %s", name, raw)
+		}
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "lexical":
+			weights.Lexical = value
+		case "length":
+			weights.Length = value
+		case "pronounceability":
+			weights.Pronounceability = value
+		case "tld":
+			weights.TLD = value
+		case "semantic":
+			weights.Semantic = value
+		default:
+			return Weights{}, fmt.Errorf("unknown scoring signal %q", name)
+		}
+	}
+	if weights == (Weights{}) {
+		return Weights{}, fmt.Errorf("every scoring signal has a zero weight")
+	}
+	return weights, nil
+}
+
+// Candidate is a suggested domain together with the search it answers.
+type Candidate struct {
+	Query         string
+	Domain        string
+	PreferredTLDs []string
+}
+
+// SemanticSimilarity rates how close a domain label is to the query in meaning, in the range [0, 1].
+type SemanticSimilarity interface {
+	Similarity(ctx context.Context, query, label string) (float64, error)
+}
+
+// Scorer rates candidates against the query by combining weighted signals into a score in [0, 1].
+type Scorer struct {
+	weights  Weights
+	semantic SemanticSimilarity
+}
+
+// New builds a Scorer. semantic may be nil, in which case the semantic weight is ignored and no semantic
+// similarity is reported.
+func New(weights Weights, semantic SemanticSimilarity) *Scorer {
+	return &Scorer{weights: weights, semantic: semantic}
+}
+
+// Rating is the relevance of a candidate together with its raw semantic similarity.
+type Rating struct {
+	// Score is the weighted blend of every signal, in [0, 1].
+	Score float64
+	// Semantic is the semantic similarity of the label to the query on its own, in [0, 1]. It is zero when the
+	// Scorer has no SemanticSimilarity or the comparison failed.
+	Semantic float64
+}
+
+// Rate rates a single candidate. Signals that fail are left out of the weighted average instead of
+// dragging the score down. The semantic similarity is reported whenever the Scorer has a SemanticSimilarity,
+// even if the semantic weight leaves it out of the score.
+func (s *Scorer) Rate(ctx context.Context, c Candidate) Rating {
+	label, tld := splitDomain(c.Domain)
+	if label == "" {
+		return Rating{}
+	}
+
+	var (
+		rating           Rating
+		total, weightSum float64
+	)
+	add := func(weight, score float64) {
+		if weight <= 0 {
+			return
+		}
+		total += weight * clamp(score)
+		weightSum += weight
+	}
+
+	add(s.weights.Lexical, lexicalOverlap(c.Query, label))
+	add(s.weights.Length, lengthScore(label))
+	add(s.weights.Pronounceability, pronounceability(label))
+	add(s.weights.TLD, tldFit(c.Query, tld, c.PreferredTLDs))
+	if s.semantic != nil {
+		if score, err := s.semantic.Similarity(ctx, c.Query, label); err == nil {
+			rating.Semantic = clamp(score)
+			add(s.weights.Semantic, score)
+		}
+	}
+
+	if weightSum > 0 {
+		rating.Score = total / weightSum
+	}
+	return rating
+}
+
+// splitDomain returns the second-level label of a domain in Unicode form, so that internationalized labels
+// can be compared with the query, and its public suffix as A-labels, the form publicsuffix and TLD lists use.
+func splitDomain(domain string) (string, string) {
+	domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+	if ascii, err := domainname.ToASCII(domain); err == nil {
+		domain = ascii
+	}
+	suffix, _ := publicsuffix.PublicSuffix(domain)
+	label := strings.TrimSuffix(strings.TrimSuffix(domain, suffix), ".")
+	if idx := strings.LastIndex(label, "."); idx != -1 {
+		label = label[idx+1:]
+	}
+	return domainname.ToUnicode(label), suffix
+}
+
+func clamp(v float64) float64 {
+	switch {
+	case v < 0:
+		return 0
+	case v > 1:
+		return 1
+	default:
+		return v
+	}
+}
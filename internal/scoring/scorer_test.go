@@ -0,0 +1,162 @@
+package scoring
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/olaysco/domain-search-llm/internal/embedding"
+)
+
+type failingSimilarity struct{}
+
+func (failingSimilarity) Similarity(context.Context, string, string) (float64, error) {
+	return 0, errors.New("embedder unavailable")
+}
+
+func TestScorerSemanticSignal(t *testing.T) {
+	ctx := context.Background()
+	semanticOnly := Weights{Semantic: 1}
+	scorer := New(semanticOnly, embedding.NewSimilarity(embedding.NewFakeEmbedder(0)))
+
+	same := scorer.Rate(ctx, Candidate{Query: "coffee shop", Domain: "coffee-shop.com"}).Score
+	if math.Abs(same-1) > 1e-6 {
+		t.Errorf("label matching the query scored %v, want 1", same)
+	}
+	near := scorer.Rate(ctx, Candidate{Query: "coffee shop", Domain: "coffeehouse.com"}).Score
+	far := scorer.Rate(ctx, Candidate{Query: "coffee shop", Domain: "xylophone.com"}).Score
+	if near <= far {
+		t.Errorf("coffeehouse.com (%v) should outscore xylophone.com (%v)", near, far)
+	}
+}
+
+func TestScorerSkipsUnavailableSemanticSignal(t *testing.T) {
+	ctx := context.Background()
+	weights := Weights{Length: 1, Semantic: 1}
+	candidate := Candidate{Query: "coffee", Domain: "coffee.com"}
+
+	want := New(Weights{Length: 1}, nil).Rate(ctx, candidate).Score
+	if got := New(weights, failingSimilarity{}).Rate(ctx, candidate).Score; got != want {
+		t.Errorf("failing semantic signal: score %v, want %v", got, want)
+	}
+	if got := New(weights, nil).Rate(ctx, candidate).Score; got != want {
+		t.Errorf("without semantic similarity: score %v, want %v", got, want)
+	}
+}
+
+func TestScorerRateReportsRawSemanticSimilarity(t *testing.T) {
+	ctx := context.Background()
+	similarity := embedding.NewSimilarity(embedding.NewFakeEmbedder(0))
+	candidate := Candidate{Query: "coffee shop", Domain: "coffeehouse.com"}
+
+	raw, err := similarity.Similarity(ctx, candidate.Query, "coffeehouse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	blended := New(DefaultWeights(), similarity).Rate(ctx, candidate)
+	if math.Abs(blended.Semantic-clamp(raw)) > 1e-9 {
+		t.Errorf("semantic similarity %v, want the raw similarity %v", blended.Semantic, clamp(raw))
+	}
+	if blended.Score == blended.Semantic {
+		t.Errorf("score %v should blend the other signals in", blended.Score)
+	}
+
+	withoutWeight := New(Weights{Length: 1}, similarity).Rate(ctx, candidate)
+	if withoutWeight.Semantic != blended.Semantic {
+		t.Errorf("zero semantic weight: semantic similarity %v, want %v", withoutWeight.Semantic, blended.Semantic)
+	}
+	if want := New(Weights{Length: 1}, nil).Rate(ctx, candidate).Score; withoutWeight.Score != want {
+		t.Errorf("zero semantic weight: score %v, want %v", withoutWeight.Score, want)
+	}
+	if got := New(DefaultWeights(), failingSimilarity{}).Rate(ctx, candidate).Semantic; got != 0 {
+		t.Errorf("failing semantic signal: semantic similarity %v, want 0", got)
+	}
+}
+
+func TestParseWeights(t *testing.T) {
+	defaults := DefaultWeights()
+	tests := []struct {
+		spec    string
+		want    Weights
+		wantErr bool
+	}{
+		{spec: "", want: defaults},
+		{spec: " , ", want: defaults},
+		{spec: "lexical=0.5, TLD=0.1", want: Weights{Lexical: 0.5, Length: defaults.Length, Pronounceability: defaults.Pronounceability, TLD: 0.1, Semantic: defaults.Semantic}},
+		{spec: "semantic=0", want: Weights{Lexical: defaults.Lexical, Length: defaults.Length, Pronounceability: defaults.Pronounceability, TLD: defaults.TLD}},
+		{spec: "lexical=0,length=0,pronounceability=0,tld=0,semantic=1", want: Weights{Semantic: 1}},
+		{spec: "colour=1", wantErr: true},
+		{spec: "lexical", wantErr: true},
+		{spec: "lexical=abc", wantErr: true},
+		{spec: "lexical=-0.1", wantErr: true},
+		{spec: "lexical=0,length=0,pronounceability=0,tld=0,semantic=0", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseWeights(tt.spec)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseWeights(%q) = %+v, want an error", tt.spec, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseWeights(%q) = %+v, %v; want %+v", tt.spec, got, err, tt.want)
+		}
+	}
+}
+
+func TestScorerStaysInUnitRange(t *testing.T) {
+	ctx := context.Background()
+	similarity := embedding.NewSimilarity(embedding.NewFakeEmbedder(0))
+	weightSets := []Weights{
+		DefaultWeights(),
+		{Lexical: 1},
+		{Length: 5, Pronounceability: 100},
+		{Lexical: 1e6, Length: 1, Pronounceability: 1, TLD: 1, Semantic: 1e6},
+	}
+	candidates := []Candidate{
+		{Query: "coffee shop", Domain: "coffeeshop.com"},
+		{Query: "coffee shop", Domain: "xq-1234567890-zzzzzzzzzzzz.store"},
+		{Query: "coffee", Domain: "co.uk"},
+		{Query: "", Domain: "a.io"},
+		{Query: "магазин", Domain: "магазин.рф", PreferredTLDs: []string{"рф"}},
+	}
+	for _, weights := range weightSets {
+		scorer := New(weights, similarity)
+		for _, c := range candidates {
+			rating := scorer.Rate(ctx, c)
+			if rating.Score < 0 || rating.Score > 1 || rating.Semantic < 0 || rating.Semantic > 1 {
+				t.Errorf("weights %+v, %s: rating %+v is outside [0, 1]", weights, c.Domain, rating)
+			}
+		}
+	}
+}
+
+func TestSplitDomainReturnsASCIISuffix(t *testing.T) {
+	tests := []struct {
+		domain, label, tld string
+	}{
+		{domain: "Brand.COM.", label: "brand", tld: "com"},
+		{domain: "shop.brand.co.uk", label: "brand", tld: "co.uk"},
+		{domain: "магазин.рф", label: "магазин", tld: "xn--p1ai"},
+		{domain: "xn--80aairftm.xn--p1ai", label: "магазин", tld: "xn--p1ai"},
+	}
+	for _, tt := range tests {
+		label, tld := splitDomain(tt.domain)
+		if label != tt.label || tld != tt.tld {
+			t.Errorf("splitDomain(%q) = %q, %q; want %q, %q", tt.domain, label, tld, tt.label, tt.tld)
+		}
+	}
+}
+
+func TestTLDFitMatchesPreferredIDNTLDs(t *testing.T) {
+	for _, preferred := range [][]string{{"xn--p1ai"}, {".рф"}, {"РФ"}} {
+		if got := tldFit("магазин", "xn--p1ai", preferred); got != 1 {
+			t.Errorf("tldFit with preferred %v = %v, want 1", preferred, got)
+		}
+	}
+	if got := tldFit("онлайн магазин", "xn--80asehdb", nil); got != 0.95 {
+		t.Errorf("tldFit for a TLD echoing the query = %v, want 0.95", got)
+	}
+}
@@ -0,0 +1,200 @@
+package scoring
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/olaysco/domain-search-llm/internal/domainname"
+)
+
+var stopwords = map[string]struct{}{
+	"a": {}, "an": {}, "and": {}, "the": {}, "for": {}, "of": {}, "in": {}, "on": {}, "to": {}, "with": {},
+	"my": {}, "our": {}, "your": {}, "domain": {}, "domains": {}, "name": {}, "names": {}, "website": {},
+	"site": {}, "business": {}, "company": {}, "brand": {}, "under": {}, "cheap": {},
+}
+
+// queryTokens splits the query into lowercase words, dropping stopwords and single characters.
+func queryTokens(query string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if len([]rune(field)) < 2 {
+			continue
+		}
+		if _, ok := stopwords[field]; ok {
+			continue
+		}
+		tokens = append(tokens, field)
+	}
+	return tokens
+}
+
+// lexicalOverlap measures how much of the query survives in the label. Whole words count fully, and
+// partial matches of at least three characters (e.g. "coffe" for "coffee") count proportionally.
+func lexicalOverlap(query, label string) float64 {
+	tokens := queryTokens(query)
+	if len(tokens) == 0 {
+		return 0.5
+	}
+	compact := strings.ReplaceAll(label, "-", "")
+
+	var sum float64
+	for _, token := range tokens {
+		if strings.Contains(compact, token) {
+			sum++
+			continue
+		}
+		common := longestCommonSubstring(compact, token)
+		if common >= 3 {
+			sum += 0.8 * float64(common) / float64(len([]rune(token)))
+		}
+	}
+	return sum / float64(len(tokens))
+}
+
+// lengthScore prefers labels between 5 and 10 characters and penalizes digits and hyphens.
+func lengthScore(label string) float64 {
+	n := len([]rune(label))
+	var score float64
+	switch {
+	case n <= 2:
+		score = 0.4
+	case n <= 4:
+		score = 0.85
+	case n <= 10:
+		score = 1
+	default:
+		score = 1 - float64(n-10)/15
+	}
+	if strings.Contains(label, "-") {
+		score -= 0.15
+	}
+	if strings.IndexFunc(label, unicode.IsDigit) != -1 {
+		score -= 0.15
+	}
+	return clamp(score)
+}
+
+// pronounceability rewards a natural mix of vowels and consonants and penalizes long runs of either.
+// Labels outside the Latin script get a neutral score since the vowel heuristics do not apply to them.
+func pronounceability(label string) float64 {
+	if strings.IndexFunc(label, func(r rune) bool { return unicode.IsLetter(r) && !unicode.Is(unicode.Latin, r) }) != -1 {
+		return 0.7
+	}
+	letters := 0
+	vowels := 0
+	penalty := 0.0
+	consonantRun, vowelRun := 0, 0
+	for _, r := range label {
+		if !unicode.IsLetter(r) {
+			consonantRun, vowelRun = 0, 0
+			penalty += 0.05
+			continue
+		}
+		letters++
+		if isVowel(r) {
+			vowels++
+			vowelRun++
+			consonantRun = 0
+			if vowelRun == 3 {
+				penalty += 0.15
+			}
+		} else {
+			consonantRun++
+			vowelRun = 0
+			if consonantRun == 4 {
+				penalty += 0.25
+			}
+		}
+	}
+	if letters == 0 {
+		return 0
+	}
+
+	ratio := float64(vowels) / float64(letters)
+	score := 1.0
+	switch {
+	case ratio < 0.2:
+		score = 0.3
+	case ratio < 0.3:
+		score = 0.7
+	case ratio > 0.7:
+		score = 0.6
+	}
+	return clamp(score - penalty)
+}
+
+func isVowel(r rune) bool {
+	switch unicode.ToLower(r) {
+	case 'a', 'e', 'i', 'o', 'u', 'y':
+		return true
+	}
+	return false
+}
+
+var popularTLDs = map[string]struct{}{
+	"net": {}, "org": {}, "io": {}, "co": {}, "ai": {}, "app": {}, "dev": {},
+}
+
+// tldFit rates the suffix, given as A-labels: requested TLDs win outright, then .com, TLDs that echo a query
+// word (e.g. "shop" with .shop), popular generic TLDs and finally everything else. Preferred TLDs may be
+// given in either form.
+func tldFit(query, tld string, preferred []string) float64 {
+	if tld == "" {
+		return 0
+	}
+	if len(preferred) > 0 {
+		for _, p := range preferred {
+			p = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(p), "."))
+			if ascii, err := domainname.ToASCII(p); err == nil {
+				p = ascii
+			}
+			if p == tld {
+				return 1
+			}
+		}
+		return 0.3
+	}
+	if tld == "com" {
+		return 1
+	}
+	unicodeTLD := domainname.ToUnicode(tld)
+	for _, token := range queryTokens(query) {
+		if token == unicodeTLD {
+			return 0.95
+		}
+	}
+	if _, ok := popularTLDs[tld]; ok {
+		return 0.75
+	}
+	if len(tld) == 2 {
+		return 0.5
+	}
+	return 0.6
+}
+
+func longestCommonSubstring(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	if len(ar) == 0 || len(br) == 0 {
+		return 0
+	}
+	prev := make([]int, len(br)+1)
+	curr := make([]int, len(br)+1)
+	best := 0
+	for i := 1; i <= len(ar); i++ {
+		for j := 1; j <= len(br); j++ {
+			if ar[i-1] == br[j-1] {
+				curr[j] = prev[j-1] + 1
+				if curr[j] > best {
+					best = curr[j]
+				}
+			} else {
+				curr[j] = 0
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return best
+}
@@ -0,0 +1,91 @@
+package scoring
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLexicalOverlap(t *testing.T) {
+	tests := []struct {
+		query, label string
+		want         float64
+	}{
+		{query: "coffee shop", label: "coffeeshop", want: 1},
+		{query: "Coffee Shop", label: "coffee-shop", want: 1},
+		{query: "coffee shop", label: "coffee", want: 0.5},
+		{query: "coffee", label: "coffe-house", want: 0.8 * 5 / 6},
+		{query: "coffee", label: "xylophone", want: 0},
+		{query: "the brand for my business", label: "anything", want: 0.5},
+		{query: "", label: "anything", want: 0.5},
+	}
+	for _, tt := range tests {
+		if got := lexicalOverlap(tt.query, tt.label); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("lexicalOverlap(%q, %q) = %v, want %v", tt.query, tt.label, got, tt.want)
+		}
+	}
+}
+
+func TestLengthScore(t *testing.T) {
+	tests := []struct {
+		label string
+		want  float64
+	}{
+		{label: "ab", want: 0.4},
+		{label: "abcd", want: 0.85},
+		{label: "coffee", want: 1},
+		{label: "abcdefghij", want: 1},
+		{label: "abcdefghijklmno", want: 1 - 5.0/15},
+		{label: "my-shop", want: 0.85},
+		{label: "shop24", want: 0.85},
+		{label: "my-shop-24", want: 0.7},
+		{label: "абвгдеж", want: 1},
+		{label: "abcdefghijklmnopqrstuvwxyz", want: 0},
+	}
+	for _, tt := range tests {
+		if got := lengthScore(tt.label); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("lengthScore(%q) = %v, want %v", tt.label, got, tt.want)
+		}
+	}
+}
+
+func TestPronounceability(t *testing.T) {
+	tests := []struct {
+		label string
+		want  float64
+	}{
+		{label: "banana", want: 1},
+		{label: "coffee", want: 1},
+		{label: "strngth", want: 0.05},
+		{label: "aeiou", want: 0.45},
+		{label: "shop-24", want: 0.55},
+		{label: "магазин", want: 0.7},
+		{label: "24", want: 0},
+	}
+	for _, tt := range tests {
+		if got := pronounceability(tt.label); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("pronounceability(%q) = %v, want %v", tt.label, got, tt.want)
+		}
+	}
+}
+
+func TestTLDFit(t *testing.T) {
+	tests := []struct {
+		query, tld string
+		preferred  []string
+		want       float64
+	}{
+		{query: "coffee", tld: "io", preferred: []string{".IO", "ai"}, want: 1},
+		{query: "coffee", tld: "com", preferred: []string{"io"}, want: 0.3},
+		{query: "coffee", tld: "com", want: 1},
+		{query: "coffee shop", tld: "shop", want: 0.95},
+		{query: "coffee", tld: "io", want: 0.75},
+		{query: "coffee", tld: "de", want: 0.5},
+		{query: "coffee", tld: "store", want: 0.6},
+		{query: "coffee", tld: "", want: 0},
+	}
+	for _, tt := range tests {
+		if got := tldFit(tt.query, tt.tld, tt.preferred); got != tt.want {
+			t.Errorf("tldFit(%q, %q, %v) = %v, want %v", tt.query, tt.tld, tt.preferred, got, tt.want)
+		}
+	}
+}
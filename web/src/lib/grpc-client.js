@@ -338,8 +338,11 @@ const decodePricePayload = (buffer) => {
     labels: [],
     availability: false,
     similarityScore: 0,
+    semanticSimilarity: 0,
     renewalCost: 0,
-    reasoning: ''
+    reasoning: '',
+    availabilityStatus: 0,
+    unicodeDomain: ''
   };
   while (offset < buffer.length) {
     const { value: tag, nextOffset } = decodeVarint(buffer, offset);
@@ -382,6 +385,22 @@ const decodePricePayload = (buffer) => {
       const { value, nextOffset: after } = readString(buffer, offset);
       price.reasoning = value;
       offset = after;
+    } else if (fieldNumber === 10 && wireType === WIRE_TYPE.VARINT) {
+      const { value, nextOffset: after } = decodeVarint(buffer, offset);
+      price.availabilityStatus = value;
+      offset = after;
+    } else if (fieldNumber === 11 && wireType === WIRE_TYPE.LENGTH_DELIMITED) {
+      const { value, nextOffset: after } = readString(buffer, offset);
+      price.unicodeDomain = value;
+      offset = after;
+    } else if (fieldNumber === 17 && wireType === WIRE_TYPE.VARINT) {
+      const { value, nextOffset: after } = decodeVarint(buffer, offset);
+      price.rank = value;
+      offset = after;
+    } else if (fieldNumber === 18 && wireType === WIRE_TYPE.FIXED64) {
+      const { value, nextOffset: after } = readFixed64(buffer, offset, true);
+      price.semanticSimilarity = value;
+      offset = after;
     } else {
       offset = skipField(wireType, buffer, offset);
     }
@@ -416,9 +435,29 @@ const decodeStatus = (buffer) => {
   return status;
 };
 
+const decodeSearchSummary = (buffer) => {
+  let offset = 0;
+  const summary = { requested: 0, priced: 0, failed: 0, overBudget: 0 };
+  const fields = { 1: 'requested', 2: 'priced', 3: 'failed', 4: 'overBudget' };
+  while (offset < buffer.length) {
+    const { value: tag, nextOffset } = decodeVarint(buffer, offset);
+    offset = nextOffset;
+    const fieldNumber = tag >>> 3;
+    const wireType = tag & 0x7;
+    if (fields[fieldNumber] && wireType === WIRE_TYPE.VARINT) {
+      const { value, nextOffset: after } = decodeVarint(buffer, offset);
+      summary[fields[fieldNumber]] = value;
+      offset = after;
+    } else {
+      offset = skipField(wireType, buffer, offset);
+    }
+  }
+  return summary;
+};
+
 const decodeSearchPricesResponse = (buffer) => {
   let offset = 0;
-  const response = { price: null, error: null };
+  const response = { price: null, error: null, summary: null, domain: '' };
   while (offset < buffer.length) {
     const { value: tag, nextOffset } = decodeVarint(buffer, offset);
     offset = nextOffset;
@@ -432,6 +471,14 @@ const decodeSearchPricesResponse = (buffer) => {
       const { value: statusBytes, nextOffset: afterStatus } = readBytes(buffer, offset);
       response.error = decodeStatus(statusBytes);
       offset = afterStatus;
+    } else if (fieldNumber === 3 && wireType === WIRE_TYPE.LENGTH_DELIMITED) {
+      const { value, nextOffset: after } = readString(buffer, offset);
+      response.domain = value;
+      offset = after;
+    } else if (fieldNumber === 4 && wireType === WIRE_TYPE.LENGTH_DELIMITED) {
+      const { value: summaryBytes, nextOffset: afterSummary } = readBytes(buffer, offset);
+      response.summary = decodeSearchSummary(summaryBytes);
+      offset = afterSummary;
     } else {
       offset = skipField(wireType, buffer, offset);
     }